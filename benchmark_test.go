@@ -0,0 +1,72 @@
+package gosortedset_test
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+var benchmarkSizes = []int{1_000_000, 10_000_000}
+
+// run f against a set of the even numbers in [0, 2n) with random queries in [0, 2n).
+func benchmarkQuery(b *testing.B, f func(ss *gosortedset.SortedSet[int], x int)) {
+	for _, n := range benchmarkSizes {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			a := make([]int, n)
+			for i := range a {
+				a[i] = i * 2
+			}
+			ss := gosortedset.New(a)
+			rng := rand.New(rand.NewPCG(1, 2))
+			queries := make([]int, 1<<16)
+			for i := range queries {
+				queries[i] = rng.IntN(2 * n)
+			}
+
+			b.ResetTimer()
+			for i := range b.N {
+				f(ss, queries[i&(len(queries)-1)])
+			}
+		})
+	}
+}
+
+func BenchmarkContains(b *testing.B) {
+	benchmarkQuery(b, func(ss *gosortedset.SortedSet[int], x int) {
+		_ = ss.Contains(x)
+	})
+}
+
+func BenchmarkLt(b *testing.B) {
+	benchmarkQuery(b, func(ss *gosortedset.SortedSet[int], x int) {
+		_, _ = ss.Lt(x)
+	})
+}
+
+func BenchmarkGe(b *testing.B) {
+	benchmarkQuery(b, func(ss *gosortedset.SortedSet[int], x int) {
+		_, _ = ss.Ge(x)
+	})
+}
+
+func BenchmarkIndex(b *testing.B) {
+	benchmarkQuery(b, func(ss *gosortedset.SortedSet[int], x int) {
+		_ = ss.Index(x)
+	})
+}
+
+func BenchmarkAddDiscard(b *testing.B) {
+	benchmarkQuery(b, func(ss *gosortedset.SortedSet[int], x int) {
+		if ss.Add(x | 1) {
+			ss.Discard(x | 1)
+		}
+	})
+}
+
+func BenchmarkGetItem(b *testing.B) {
+	benchmarkQuery(b, func(ss *gosortedset.SortedSet[int], x int) {
+		_, _ = ss.GetItem(x / 2)
+	})
+}
@@ -0,0 +1,213 @@
+package gosortedset
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// The binary format is
+//
+//	version byte | kind byte | uvarint number of elements | elements
+//
+// where the elements are encoded in ascending order depending on their kind:
+//   - integers: the first one as a varint (uvarint if unsigned), and then the differences from the previous one as uvarints
+//   - floats: IEEE 754 binary64 in little endian
+//   - strings: the length of the prefix shared with the previous one, the length of the rest and the rest
+const binaryVersion = 1
+
+type elemKind byte
+
+const (
+	kindInt elemKind = iota + 1
+	kindUint
+	kindFloat
+	kindString
+)
+
+func kindOf[T cmp.Ordered]() elemKind {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return kindUint
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	default:
+		return kindString
+	}
+}
+
+// elemEncoder appends elements given in ascending order.
+type elemEncoder[T cmp.Ordered] struct {
+	kind    elemKind
+	started bool
+	prevInt uint64
+	prevStr string
+}
+
+func (e *elemEncoder[T]) append(buf []byte, v T) []byte {
+	rv := reflect.ValueOf(v)
+	switch e.kind {
+	case kindInt:
+		x := uint64(rv.Int())
+		if e.started {
+			buf = binary.AppendUvarint(buf, x-e.prevInt)
+		} else {
+			buf = binary.AppendVarint(buf, int64(x))
+		}
+		e.prevInt = x
+	case kindUint:
+		x := rv.Uint()
+		if e.started {
+			buf = binary.AppendUvarint(buf, x-e.prevInt)
+		} else {
+			buf = binary.AppendUvarint(buf, x)
+		}
+		e.prevInt = x
+	case kindFloat:
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(rv.Float()))
+	case kindString:
+		x := rv.String()
+		shared := 0
+		for shared < len(x) && shared < len(e.prevStr) && x[shared] == e.prevStr[shared] {
+			shared++
+		}
+		buf = binary.AppendUvarint(buf, uint64(shared))
+		buf = binary.AppendUvarint(buf, uint64(len(x)-shared))
+		buf = append(buf, x[shared:]...)
+		e.prevStr = x
+	}
+	e.started = true
+	return buf
+}
+
+// elemDecoder reads elements written by elemEncoder.
+type elemDecoder[T cmp.Ordered] struct {
+	kind    elemKind
+	started bool
+	prevInt uint64
+	prevStr string
+}
+
+func (d *elemDecoder[T]) read(data []byte) (T, []byte, error) {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	switch d.kind {
+	case kindInt, kindUint:
+		var x uint64
+		var n int
+		if !d.started && d.kind == kindInt {
+			var y int64
+			y, n = binary.Varint(data)
+			x = uint64(y)
+		} else {
+			x, n = binary.Uvarint(data)
+			if d.started {
+				x += d.prevInt
+			}
+		}
+		if n <= 0 {
+			return v, nil, fmt.Errorf("%w: malformed integer", ErrInvalidEncoding)
+		}
+		data = data[n:]
+		if d.kind == kindInt {
+			if rv.OverflowInt(int64(x)) {
+				return v, nil, fmt.Errorf("%w: integer overflows %v", ErrInvalidEncoding, rv.Type())
+			}
+			rv.SetInt(int64(x))
+		} else {
+			if rv.OverflowUint(x) {
+				return v, nil, fmt.Errorf("%w: integer overflows %v", ErrInvalidEncoding, rv.Type())
+			}
+			rv.SetUint(x)
+		}
+		d.prevInt = x
+	case kindFloat:
+		if len(data) < 8 {
+			return v, nil, fmt.Errorf("%w: malformed float", ErrInvalidEncoding)
+		}
+		rv.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+		data = data[8:]
+	case kindString:
+		shared, n := binary.Uvarint(data)
+		if n <= 0 || shared > uint64(len(d.prevStr)) {
+			return v, nil, fmt.Errorf("%w: malformed string", ErrInvalidEncoding)
+		}
+		data = data[n:]
+		rest, n := binary.Uvarint(data)
+		if n <= 0 || rest > uint64(len(data)-n) {
+			return v, nil, fmt.Errorf("%w: malformed string", ErrInvalidEncoding)
+		}
+		data = data[n:]
+		x := d.prevStr[:shared] + string(data[:rest])
+		data = data[rest:]
+		rv.SetString(x)
+		d.prevStr = x
+	}
+	d.started = true
+	return v, data, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// Integers are delta encoded and strings are prefix compressed, making use of the elements being sorted.
+func (s *SortedSet[T]) MarshalBinary() ([]byte, error) {
+	kind := kindOf[T]()
+	buf := []byte{binaryVersion, byte(kind)}
+	buf = binary.AppendUvarint(buf, uint64(s.size))
+	e := &elemEncoder[T]{kind: kind}
+	for v := range s.Values() {
+		buf = e.append(buf, v)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces the elements of s with the ones encoded by MarshalBinary.
+func (s *SortedSet[T]) UnmarshalBinary(data []byte) error {
+	kind := kindOf[T]()
+	if len(data) < 2 {
+		return fmt.Errorf("%w: too short", ErrInvalidEncoding)
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, data[0])
+	}
+	if elemKind(data[1]) != kind {
+		return fmt.Errorf("%w: element kind %d does not match %v", ErrInvalidEncoding, data[1], reflect.TypeFor[T]())
+	}
+	n, m := binary.Uvarint(data[2:])
+	// every element takes at least one byte, which bounds the allocation below
+	if m <= 0 || n > uint64(len(data)) {
+		return fmt.Errorf("%w: malformed length", ErrInvalidEncoding)
+	}
+	data = data[2+m:]
+
+	a := make([]T, 0, n)
+	d := &elemDecoder[T]{kind: kind}
+	for range n {
+		v, rest, err := d.read(data)
+		if err != nil {
+			return err
+		}
+		a = append(a, v)
+		data = rest
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, len(data))
+	}
+
+	s.reset(sortUnique(a))
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder with the same format as MarshalBinary.
+func (s *SortedSet[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder with the same format as UnmarshalBinary.
+func (s *SortedSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
@@ -0,0 +1,185 @@
+package gosortedset_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+type userID int32
+
+func testBinaryRoundTrip[T interface {
+	~int | ~int8 | ~int32 | ~uint64 | ~float64 | ~string
+}](t *testing.T, initial []T) {
+	t.Helper()
+
+	expected := slices.Collect(gosortedset.New(slices.Clone(initial)).Values())
+	data, err := gosortedset.New(initial).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded := gosortedset.New([]T{})
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertEqualSlice(t, expected, slices.Collect(decoded.Values()))
+	if decoded.Len() != len(expected) {
+		t.Errorf("expected length %v, got %v", len(expected), decoded.Len())
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("int", func(t *testing.T) {
+		t.Parallel()
+		testBinaryRoundTrip(t, []int{-5, 3, 1000000, math.MinInt, math.MaxInt, 0})
+	})
+	t.Run("int8", func(t *testing.T) {
+		t.Parallel()
+		testBinaryRoundTrip(t, []int8{math.MinInt8, -1, 0, math.MaxInt8})
+	})
+	t.Run("named type", func(t *testing.T) {
+		t.Parallel()
+		testBinaryRoundTrip(t, []userID{3, 1, 2})
+	})
+	t.Run("uint64", func(t *testing.T) {
+		t.Parallel()
+		testBinaryRoundTrip(t, []uint64{0, 1, math.MaxUint64})
+	})
+	t.Run("float64", func(t *testing.T) {
+		t.Parallel()
+		testBinaryRoundTrip(t, []float64{-1.5, 0, 3.25, math.Inf(1), math.SmallestNonzeroFloat64})
+	})
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		testBinaryRoundTrip(t, []string{"", "apple", "application", "apply", "banana", "日本語", "日本"})
+	})
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		testBinaryRoundTrip(t, []int{})
+	})
+	t.Run("multiple buckets", func(t *testing.T) {
+		t.Parallel()
+		a := make([]int, 0, 1000)
+		for i := range 1000 {
+			a = append(a, i*i)
+		}
+		testBinaryRoundTrip(t, a)
+	})
+}
+
+func TestMarshalBinaryCompact(t *testing.T) {
+	t.Parallel()
+
+	a := make([]int, 0, 1000)
+	for i := range 1000 {
+		a = append(a, 1_000_000_000+i*3)
+	}
+	data, err := gosortedset.New(a).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// header, length and the first element, then one byte for each delta
+	if len(data) > 2+2+5+999 {
+		t.Errorf("expected integers to be delta encoded, got %d bytes", len(data))
+	}
+
+	s := make([]string, 0, 100)
+	for i := range 100 {
+		s = append(s, "https://example.com/items/"+string(rune('a'+i/26))+string(rune('a'+i%26)))
+	}
+	data, err = gosortedset.New(s).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) > 2+1+len(s[0])+2+99*4 {
+		t.Errorf("expected strings to be prefix compressed, got %d bytes", len(data))
+	}
+}
+
+func TestUnmarshalBinaryError(t *testing.T) {
+	t.Parallel()
+
+	valid, err := gosortedset.New([]int{1, 2, 300}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stringData, err := gosortedset.New([]string{"a", "b"}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	overflow, err := gosortedset.New([]int{1000}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := map[string]struct {
+		data []byte
+		into func(data []byte) error
+	}{
+		"empty": {
+			data: []byte{},
+			into: gosortedset.New([]int{}).UnmarshalBinary,
+		},
+		"unsupported version": {
+			data: append([]byte{99}, valid[1:]...),
+			into: gosortedset.New([]int{}).UnmarshalBinary,
+		},
+		"kind mismatch": {
+			data: stringData,
+			into: gosortedset.New([]int{}).UnmarshalBinary,
+		},
+		"truncated": {
+			data: valid[:len(valid)-1],
+			into: gosortedset.New([]int{}).UnmarshalBinary,
+		},
+		"trailing bytes": {
+			data: append(slices.Clone(valid), 0),
+			into: gosortedset.New([]int{}).UnmarshalBinary,
+		},
+		"overflow": {
+			data: overflow,
+			into: gosortedset.New([]int8{}).UnmarshalBinary,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := testCase.into(testCase.data); !errors.Is(err, gosortedset.ErrInvalidEncoding) {
+				t.Errorf("expected error %v, got %v", gosortedset.ErrInvalidEncoding, err)
+			}
+		})
+	}
+}
+
+func TestGob(t *testing.T) {
+	t.Parallel()
+
+	type cache struct {
+		Name string
+		IDs  *gosortedset.SortedSet[int64]
+	}
+
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(cache{Name: "ids", IDs: gosortedset.New([]int64{3, -1, 2})}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded cache
+	if err := gob.NewDecoder(buf).Decode(&decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.Name != "ids" {
+		t.Errorf("expected %v, got %v", "ids", decoded.Name)
+	}
+	assertEqualSlice(t, []int64{-1, 2, 3}, slices.Collect(decoded.IDs.Values()))
+}
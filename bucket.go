@@ -0,0 +1,63 @@
+package gosortedset
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+)
+
+// return the number of buckets for n elements, which is about √(n/bucketRatio).
+func bucketCount(n int, o *options) int {
+	return int(math.Ceil(math.Sqrt(float64(n) / float64(o.bucketRatio))))
+}
+
+// split a sorted slice into about √(n/bucketRatio) buckets of almost the same size.
+// If o reserves more capacity than len(a), the buckets are allocated with room to grow up to the split bound.
+func bucketize[T any](a []T, o *options) [][]T {
+	n := len(a)
+	numBucket := bucketCount(n, o)
+	// with a split ratio below the bucket ratio, the buckets would be built already too large
+	for numBucket > 0 && (n+numBucket-1)/numBucket > numBucket*o.splitRatio {
+		numBucket++
+	}
+	bucketCap := 0
+	if numBucket > 0 {
+		bucketCap = max(n/numBucket, min(o.capacity/numBucket, numBucket*o.splitRatio+1))
+	}
+
+	buckets := make([][]T, numBucket, max(numBucket, bucketCount(o.capacity, o)))
+	for i := 0; i < numBucket; i++ {
+		buckets[i] = make([]T, 0, bucketCap)
+		buckets[i] = append(buckets[i], a[i*n/numBucket:(i+1)*n/numBucket]...)
+	}
+	return buckets
+}
+
+// split buckets[b] into two halves if it is too large.
+// The left half is clipped so that appending to it never overwrites the right half.
+func splitBucket[T any](buckets [][]T, b int, o *options) [][]T {
+	a := buckets[b]
+	if len(a) <= len(buckets)*o.splitRatio {
+		return buckets
+	}
+	mid := len(a) >> 1
+	buckets = append(buckets, nil)
+	copy(buckets[b+2:], buckets[b+1:])
+	buckets[b] = a[:mid:mid]
+	buckets[b+1] = a[mid:]
+	return buckets
+}
+
+// return the first index i such that a[i] > x.
+func bisectRight[T cmp.Ordered](a []T, x T) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+// merge buckets[b] and buckets[b+1] into a new bucket, and split it again if it is too large.
+// This is used to fix up a boundary which may have left a tiny bucket behind.
+func joinBuckets[T any](buckets [][]T, b int, o *options) [][]T {
+	buckets[b] = slices.Concat(buckets[b], buckets[b+1])
+	buckets = slices.Delete(buckets, b+1, b+2)
+	return splitBucket(buckets, b, o)
+}
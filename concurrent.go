@@ -0,0 +1,381 @@
+package gosortedset
+
+import (
+	"cmp"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// ConcurrentSortedSet is a SortedSet which can be shared between goroutines.
+// Every method is protected by a sync.RWMutex. Iterators hold the read lock until the loop ends,
+// so the loop body must not call any method of the set, not even a read-only one:
+// a second read lock blocks as soon as a writer is waiting, which deadlocks the loop.
+// To read the set in the loop body, iterate the set given by View, or iterate a Snapshot without any lock.
+// Methods which take another *SortedSet do not lock it; the caller must not modify it at the same time.
+//
+// The zero value is an empty set ready to use, so a ConcurrentSortedSet can be a field of a shared struct
+// like sync.Mutex. It must not be copied after first use.
+type ConcurrentSortedSet[T cmp.Ordered] struct {
+	mu sync.RWMutex
+	s  SortedSet[T]
+}
+
+func NewConcurrent[T cmp.Ordered](a []T) *ConcurrentSortedSet[T] {
+	c := &ConcurrentSortedSet[T]{}
+	c.s.reset(sortUnique(a))
+
+	return c
+}
+
+// View calls f with the underlying set while holding the read lock.
+// f must not modify the set nor keep it after returning.
+func (c *ConcurrentSortedSet[T]) View(f func(s *SortedSet[T])) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	f(&c.s)
+}
+
+// Update calls f with the underlying set while holding the write lock,
+// so that a sequence of operations is applied atomically.
+// f must not keep the set after returning.
+func (c *ConcurrentSortedSet[T]) Update(f func(s *SortedSet[T])) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f(&c.s)
+}
+
+// Snapshot returns a copy-on-write snapshot of the set, which can be read without holding any lock.
+// It takes the write lock because it marks the buckets as shared.
+func (c *ConcurrentSortedSet[T]) Snapshot() *SortedSet[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.Snapshot()
+}
+
+// wrap the iterator built by seq so that it is built and run while holding the read lock.
+// The loop body runs under the lock too, so it must not call methods of the ConcurrentSortedSet.
+func readLocked[V any](mu *sync.RWMutex, seq func() iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		mu.RLock()
+		defer mu.RUnlock()
+		seq()(yield)
+	}
+}
+
+func readLocked2[K, V any](mu *sync.RWMutex, seq func() iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		mu.RLock()
+		defer mu.RUnlock()
+		seq()(yield)
+	}
+}
+
+func (c *ConcurrentSortedSet[T]) All() iter.Seq2[int, T] {
+	return readLocked2(&c.mu, func() iter.Seq2[int, T] { return c.s.All() })
+}
+
+func (c *ConcurrentSortedSet[T]) Values() iter.Seq[T] {
+	return readLocked(&c.mu, func() iter.Seq[T] { return c.s.Values() })
+}
+
+func (c *ConcurrentSortedSet[T]) Backward() iter.Seq2[int, T] {
+	return readLocked2(&c.mu, func() iter.Seq2[int, T] { return c.s.Backward() })
+}
+
+func (c *ConcurrentSortedSet[T]) ValuesFrom(x T, inclusive bool) iter.Seq2[int, T] {
+	return readLocked2(&c.mu, func() iter.Seq2[int, T] { return c.s.ValuesFrom(x, inclusive) })
+}
+
+func (c *ConcurrentSortedSet[T]) BackwardFrom(x T, inclusive bool) iter.Seq2[int, T] {
+	return readLocked2(&c.mu, func() iter.Seq2[int, T] { return c.s.BackwardFrom(x, inclusive) })
+}
+
+func (c *ConcurrentSortedSet[T]) Slice(i, j int) iter.Seq2[int, T] {
+	return readLocked2(&c.mu, func() iter.Seq2[int, T] { return c.s.Slice(i, j) })
+}
+
+func (c *ConcurrentSortedSet[T]) Range(lo, hi T) iter.Seq[T] {
+	return readLocked(&c.mu, func() iter.Seq[T] { return c.s.Range(lo, hi) })
+}
+
+func (c *ConcurrentSortedSet[T]) RangeInclusive(lo, hi T) iter.Seq[T] {
+	return readLocked(&c.mu, func() iter.Seq[T] { return c.s.RangeInclusive(lo, hi) })
+}
+
+func (c *ConcurrentSortedSet[T]) RangeBackward(lo, hi T) iter.Seq[T] {
+	return readLocked(&c.mu, func() iter.Seq[T] { return c.s.RangeBackward(lo, hi) })
+}
+
+func (c *ConcurrentSortedSet[T]) RangeBackwardInclusive(lo, hi T) iter.Seq[T] {
+	return readLocked(&c.mu, func() iter.Seq[T] { return c.s.RangeBackwardInclusive(lo, hi) })
+}
+
+func (c *ConcurrentSortedSet[T]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Len()
+}
+
+func (c *ConcurrentSortedSet[T]) Equals(other *SortedSet[T]) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Equals(other)
+}
+
+func (c *ConcurrentSortedSet[T]) Compare(other *SortedSet[T]) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Compare(other)
+}
+
+func (c *ConcurrentSortedSet[T]) String() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.String()
+}
+
+func (c *ConcurrentSortedSet[T]) Contains(x T) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Contains(x)
+}
+
+func (c *ConcurrentSortedSet[T]) Add(x T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.Add(x)
+}
+
+// AddIfAbsentThen adds x, and if x was not in the set, calls then with the underlying set
+// before releasing the write lock. It returns whether x was added.
+func (c *ConcurrentSortedSet[T]) AddIfAbsentThen(x T, then func(s *SortedSet[T])) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.s.Add(x) {
+		return false
+	}
+	then(&c.s)
+	return true
+}
+
+// AddAll collects seq before taking the lock, so seq may read this set.
+func (c *ConcurrentSortedSet[T]) AddAll(seq iter.Seq[T]) int {
+	a := slices.Collect(seq)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.addSlice(a)
+}
+
+func (c *ConcurrentSortedSet[T]) AddSlice(a []T) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.AddSlice(a)
+}
+
+func (c *ConcurrentSortedSet[T]) Discard(x T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.Discard(x)
+}
+
+func (c *ConcurrentSortedSet[T]) DeleteRange(lo, hi T) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.DeleteRange(lo, hi)
+}
+
+func (c *ConcurrentSortedSet[T]) Lt(x T) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Lt(x)
+}
+
+func (c *ConcurrentSortedSet[T]) Le(x T) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Le(x)
+}
+
+func (c *ConcurrentSortedSet[T]) Gt(x T) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Gt(x)
+}
+
+func (c *ConcurrentSortedSet[T]) Ge(x T) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Ge(x)
+}
+
+func (c *ConcurrentSortedSet[T]) GetItem(idx int) (T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.GetItem(idx)
+}
+
+func (c *ConcurrentSortedSet[T]) Pop(idx int) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.Pop(idx)
+}
+
+func (c *ConcurrentSortedSet[T]) Min() (T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Min()
+}
+
+func (c *ConcurrentSortedSet[T]) Max() (T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Max()
+}
+
+func (c *ConcurrentSortedSet[T]) PopMin() (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.PopMin()
+}
+
+func (c *ConcurrentSortedSet[T]) PopMax() (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.PopMax()
+}
+
+func (c *ConcurrentSortedSet[T]) PopMinN(k int) []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.PopMinN(k)
+}
+
+func (c *ConcurrentSortedSet[T]) PopMaxN(k int) []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.PopMaxN(k)
+}
+
+func (c *ConcurrentSortedSet[T]) PopRange(i, j int) ([]T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.PopRange(i, j)
+}
+
+func (c *ConcurrentSortedSet[T]) Index(x T) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Index(x)
+}
+
+func (c *ConcurrentSortedSet[T]) IndexRight(x T) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.IndexRight(x)
+}
+
+func (c *ConcurrentSortedSet[T]) UnionWith(other *SortedSet[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.s.UnionWith(other)
+}
+
+func (c *ConcurrentSortedSet[T]) IntersectWith(other *SortedSet[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.s.IntersectWith(other)
+}
+
+func (c *ConcurrentSortedSet[T]) DifferenceWith(other *SortedSet[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.s.DifferenceWith(other)
+}
+
+func (c *ConcurrentSortedSet[T]) SymmetricDifferenceWith(other *SortedSet[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.s.SymmetricDifferenceWith(other)
+}
+
+func (c *ConcurrentSortedSet[T]) IsSubsetOf(other *SortedSet[T]) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.IsSubsetOf(other)
+}
+
+func (c *ConcurrentSortedSet[T]) IsSupersetOf(other *SortedSet[T]) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.IsSupersetOf(other)
+}
+
+func (c *ConcurrentSortedSet[T]) IsDisjoint(other *SortedSet[T]) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.IsDisjoint(other)
+}
+
+func (c *ConcurrentSortedSet[T]) MarshalJSON() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.MarshalJSON()
+}
+
+func (c *ConcurrentSortedSet[T]) UnmarshalJSON(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.UnmarshalJSON(data)
+}
+
+func (c *ConcurrentSortedSet[T]) MarshalBinary() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.MarshalBinary()
+}
+
+func (c *ConcurrentSortedSet[T]) UnmarshalBinary(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.UnmarshalBinary(data)
+}
+
+func (c *ConcurrentSortedSet[T]) GobEncode() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.GobEncode()
+}
+
+func (c *ConcurrentSortedSet[T]) GobDecode(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.GobDecode(data)
+}
+
+// WriteTo holds the read lock until the whole set is written to w.
+func (c *ConcurrentSortedSet[T]) WriteTo(w io.Writer) (int64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.WriteTo(w)
+}
+
+// ReadFrom holds the write lock until the whole stream is read from r.
+func (c *ConcurrentSortedSet[T]) ReadFrom(r io.Reader) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s.ReadFrom(r)
+}
+
+func (c *ConcurrentSortedSet[T]) Validate() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.Validate()
+}
+
+func (c *ConcurrentSortedSet[T]) IntersectionLen(other *SortedSet[T]) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.s.IntersectionLen(other)
+}
@@ -0,0 +1,250 @@
+package gosortedset_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestConcurrentAdd(t *testing.T) {
+	t.Parallel()
+
+	cs := gosortedset.NewConcurrent([]int{})
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := g; i < 800; i += 8 {
+				cs.Add(i)
+				_ = cs.Contains(i)
+				_ = cs.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	expected := make([]int, 0, 800)
+	for i := range 800 {
+		expected = append(expected, i)
+	}
+	assertEqualSlice(t, expected, slices.Collect(cs.Values()))
+}
+
+func TestConcurrentAddIfAbsentThen(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       []int
+		arg           int
+		expected      bool
+		expectedCalls int
+		result        []int
+	}{
+		"absent": {
+			initial:       []int{1, 3},
+			arg:           2,
+			expected:      true,
+			expectedCalls: 1,
+			result:        []int{1, 2, 3},
+		},
+		"present": {
+			initial:       []int{1, 2, 3},
+			arg:           2,
+			expected:      false,
+			expectedCalls: 0,
+			result:        []int{1, 2, 3},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cs := gosortedset.NewConcurrent(testCase.initial)
+			calls := 0
+			result := cs.AddIfAbsentThen(testCase.arg, func(s *gosortedset.SortedSet[int]) {
+				calls++
+				if !s.Contains(testCase.arg) {
+					t.Errorf("expected %v to be added before then is called", testCase.arg)
+				}
+			})
+			if result != testCase.expected || calls != testCase.expectedCalls {
+				t.Errorf("expected %v, %v calls, got %v, %v calls", testCase.expected, testCase.expectedCalls, result, calls)
+			}
+			assertEqualSlice(t, testCase.result, slices.Collect(cs.Values()))
+		})
+	}
+}
+
+func TestConcurrentPopMinMax(t *testing.T) {
+	t.Parallel()
+
+	cs := gosortedset.NewConcurrent([]int{3, 1, 2})
+	if v, err := cs.PopMin(); v != 1 || err != nil {
+		t.Errorf("expected 1, nil, got %v, %v", v, err)
+	}
+	if v, err := cs.PopMax(); v != 3 || err != nil {
+		t.Errorf("expected 3, nil, got %v, %v", v, err)
+	}
+	if v, err := cs.PopMax(); v != 2 || err != nil {
+		t.Errorf("expected 2, nil, got %v, %v", v, err)
+	}
+	if _, err := cs.PopMin(); !errors.Is(err, gosortedset.ErrEmpty) {
+		t.Errorf("expected error %v, got %v", gosortedset.ErrEmpty, err)
+	}
+}
+
+func TestConcurrentIterationHoldsReadLock(t *testing.T) {
+	t.Parallel()
+
+	cs := gosortedset.NewConcurrent([]int{1, 2, 3})
+	added := make(chan struct{})
+
+	actual := make([]int, 0, 3)
+	for v := range cs.Values() {
+		if v == 1 {
+			go func() {
+				cs.Add(0)
+				close(added)
+			}()
+		}
+		select {
+		case <-added:
+			t.Fatal("Add must wait until the iteration ends")
+		case <-time.After(10 * time.Millisecond):
+		}
+		actual = append(actual, v)
+	}
+
+	<-added
+	assertEqualSlice(t, []int{1, 2, 3}, actual)
+	assertEqualSlice(t, []int{0, 1, 2, 3}, slices.Collect(cs.Values()))
+}
+
+func TestConcurrentEncoding(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encode func(cs *gosortedset.ConcurrentSortedSet[int]) ([]byte, error)
+		decode func(cs *gosortedset.ConcurrentSortedSet[int], data []byte) error
+	}{
+		"json": {
+			encode: func(cs *gosortedset.ConcurrentSortedSet[int]) ([]byte, error) { return json.Marshal(cs) },
+			decode: func(cs *gosortedset.ConcurrentSortedSet[int], data []byte) error { return json.Unmarshal(data, cs) },
+		},
+		"binary": {
+			encode: (*gosortedset.ConcurrentSortedSet[int]).MarshalBinary,
+			decode: (*gosortedset.ConcurrentSortedSet[int]).UnmarshalBinary,
+		},
+		"gob": {
+			encode: func(cs *gosortedset.ConcurrentSortedSet[int]) ([]byte, error) {
+				buf := &bytes.Buffer{}
+				err := gob.NewEncoder(buf).Encode(cs)
+				return buf.Bytes(), err
+			},
+			decode: func(cs *gosortedset.ConcurrentSortedSet[int], data []byte) error {
+				return gob.NewDecoder(bytes.NewReader(data)).Decode(cs)
+			},
+		},
+		"stream": {
+			encode: func(cs *gosortedset.ConcurrentSortedSet[int]) ([]byte, error) {
+				buf := &bytes.Buffer{}
+				_, err := cs.WriteTo(buf)
+				return buf.Bytes(), err
+			},
+			decode: func(cs *gosortedset.ConcurrentSortedSet[int], data []byte) error {
+				_, err := cs.ReadFrom(bytes.NewReader(data))
+				return err
+			},
+		},
+	}
+
+	expected := make([]int, 0, 100)
+	for i := range 100 {
+		expected = append(expected, i*3)
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := testCase.encode(gosortedset.NewConcurrent(slices.Clone(expected)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			cs := gosortedset.NewConcurrent([]int{1, 2})
+			if err := testCase.decode(cs, data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertEqualSlice(t, expected, slices.Collect(cs.Values()))
+
+			// the zero value can be decoded into as well
+			var zero gosortedset.ConcurrentSortedSet[int]
+			if err := testCase.decode(&zero, data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertEqualSlice(t, expected, slices.Collect(zero.Values()))
+		})
+	}
+
+	t.Run("zero value field", func(t *testing.T) {
+		t.Parallel()
+
+		var v struct {
+			Name string
+			Set  gosortedset.ConcurrentSortedSet[int]
+		}
+		// the set is left out of the JSON, so it stays the zero value
+		if err := json.Unmarshal([]byte(`{"Name":"a"}`), &v); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v.Set.Len() != 0 {
+			t.Errorf("expected empty set, got %v", &v.Set)
+		}
+		data, err := v.Set.MarshalJSON()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != "[]" {
+			t.Errorf("expected [], got %s", data)
+		}
+	})
+
+	t.Run("json array", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(gosortedset.NewConcurrent([]int{3, 1, 2}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != "[1,2,3]" {
+			t.Errorf("expected [1,2,3], got %s", data)
+		}
+	})
+}
+
+func TestConcurrentZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var cs gosortedset.ConcurrentSortedSet[int]
+	if cs.Len() != 0 || cs.Contains(1) {
+		t.Errorf("expected an empty set, got %v", &cs)
+	}
+	if _, err := cs.Min(); !errors.Is(err, gosortedset.ErrEmpty) {
+		t.Errorf("expected error %v, got %v", gosortedset.ErrEmpty, err)
+	}
+	cs.Add(2)
+	cs.AddSlice([]int{3, 1})
+	assertEqualSlice(t, []int{1, 2, 3}, slices.Collect(cs.Values()))
+	cs.Update(func(s *gosortedset.SortedSet[int]) { s.Discard(2) })
+	assertEqualSlice(t, []int{1, 3}, slices.Collect(cs.Snapshot().Values()))
+}
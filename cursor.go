@@ -0,0 +1,133 @@
+package gosortedset
+
+import "cmp"
+
+// Cursor points to a position in a SortedSet and remembers its bucket and offset,
+// so that moving to a neighbouring element takes O(1) time instead of a new search.
+//
+// Besides the elements, a cursor can be positioned before the first element or after the last one,
+// where Valid reports false. Any change to the set which is not made through the cursor's own Delete
+// invalidates the cursor, and calling its methods after that panics with ErrModified.
+type Cursor[T cmp.Ordered] struct {
+	s *SortedSet[T]
+	// b is -1 before the first element and len(s.buckets) after the last one.
+	b, i int
+	mods uint64
+}
+
+func (s *SortedSet[T]) cursor(b, i int) *Cursor[T] {
+	return &Cursor[T]{s: s, b: b, i: i, mods: s.mods}
+}
+
+// Seek returns a cursor at the first element which is >= x, or after the last element if there is none.
+func (s *SortedSet[T]) Seek(x T) *Cursor[T] {
+	return s.cursor(s.bisect(x, false))
+}
+
+// First returns a cursor at the smallest element, or after the last element if s is empty.
+func (s *SortedSet[T]) First() *Cursor[T] {
+	return s.cursor(0, 0)
+}
+
+// Last returns a cursor at the largest element, or before the first element if s is empty.
+func (s *SortedSet[T]) Last() *Cursor[T] {
+	if s.size == 0 {
+		return s.cursor(-1, 0)
+	}
+	b := len(s.buckets) - 1
+	return s.cursor(b, len(s.buckets[b])-1)
+}
+
+// At returns a cursor at the idx-th element. Negative indices count from the end as in GetItem,
+// and idx == s.Len() gives a cursor after the last element. Other indices return ErrIndexOutOfRange.
+func (s *SortedSet[T]) At(idx int) (*Cursor[T], error) {
+	if idx < 0 {
+		idx += s.size
+	}
+	if idx < 0 || idx > s.size {
+		return nil, ErrIndexOutOfRange
+	}
+	return s.cursor(s.locate(idx)), nil
+}
+
+// panic if the set was modified since the cursor was created or last deleted an element.
+func (c *Cursor[T]) check() {
+	if c.mods != c.s.mods {
+		panic(ErrModified)
+	}
+}
+
+// Valid reports whether c points to an element.
+func (c *Cursor[T]) Valid() bool {
+	c.check()
+	return c.b >= 0 && c.b < len(c.s.buckets)
+}
+
+// Next moves c to the next element and reports whether there is one.
+// From before the first element, it moves to the first element.
+func (c *Cursor[T]) Next() bool {
+	c.check()
+	switch {
+	case c.b >= len(c.s.buckets):
+		return false
+	case c.b < 0:
+		c.b, c.i = 0, 0
+	case c.i+1 < len(c.s.buckets[c.b]):
+		c.i++
+	default:
+		c.b, c.i = c.b+1, 0
+	}
+	return c.b < len(c.s.buckets)
+}
+
+// Prev moves c to the previous element and reports whether there is one.
+// From after the last element, it moves to the last element.
+func (c *Cursor[T]) Prev() bool {
+	c.check()
+	switch {
+	case c.b < 0:
+		return false
+	case c.b < len(c.s.buckets) && c.i > 0:
+		c.i--
+	default:
+		c.b--
+		if c.b < 0 {
+			c.i = 0
+			return false
+		}
+		c.i = len(c.s.buckets[c.b]) - 1
+	}
+	return true
+}
+
+// Value returns the element c points to. It panics if c is not Valid.
+func (c *Cursor[T]) Value() T {
+	if !c.Valid() {
+		panic("gosortedset: Value of a cursor which does not point to an element")
+	}
+	return c.s.buckets[c.b][c.i]
+}
+
+// Index returns the index of the element c points to.
+// It returns -1 before the first element and s.Len() after the last one.
+func (c *Cursor[T]) Index() int {
+	c.check()
+	if c.b < 0 {
+		return -1
+	}
+	return c.s.offset(c.b, c.i)
+}
+
+// Delete removes the element c points to and moves c to the next element.
+// It reports whether there is a next element, and panics if c is not Valid.
+// Other cursors of the set are invalidated.
+func (c *Cursor[T]) Delete() bool {
+	if !c.Valid() {
+		panic("gosortedset: Delete of a cursor which does not point to an element")
+	}
+	idx := c.s.offset(c.b, c.i)
+	_ = c.s.pop(c.b, c.i)
+	c.b, c.i = c.s.locate(idx)
+	c.mods = c.s.mods
+	return c.b < len(c.s.buckets)
+}
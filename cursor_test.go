@@ -0,0 +1,279 @@
+package gosortedset_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+var cursorInitial = []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34}
+
+func TestCursorSeek(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       []int
+		arg           int
+		expectedValid bool
+		expectedValue int
+		expectedIndex int
+	}{
+		"contains": {
+			initial:       cursorInitial,
+			arg:           18,
+			expectedValid: true,
+			expectedValue: 18,
+			expectedIndex: 8,
+		},
+		"not contains": {
+			initial:       cursorInitial,
+			arg:           17,
+			expectedValid: true,
+			expectedValue: 18,
+			expectedIndex: 8,
+		},
+		"before first": {
+			initial:       cursorInitial,
+			arg:           0,
+			expectedValid: true,
+			expectedValue: 2,
+			expectedIndex: 0,
+		},
+		"after last": {
+			initial:       cursorInitial,
+			arg:           35,
+			expectedValid: false,
+			expectedIndex: 17,
+		},
+		"empty": {
+			initial:       []int{},
+			arg:           1,
+			expectedValid: false,
+			expectedIndex: 0,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(slices.Clone(testCase.initial))
+			c := ss.Seek(testCase.arg)
+			if c.Valid() != testCase.expectedValid {
+				t.Fatalf("expected valid %v, got %v", testCase.expectedValid, c.Valid())
+			}
+			if c.Valid() && c.Value() != testCase.expectedValue {
+				t.Errorf("expected value %v, got %v", testCase.expectedValue, c.Value())
+			}
+			if c.Index() != testCase.expectedIndex {
+				t.Errorf("expected index %v, got %v", testCase.expectedIndex, c.Index())
+			}
+		})
+	}
+}
+
+func TestCursorNextPrev(t *testing.T) {
+	t.Parallel()
+
+	ss := gosortedset.New(slices.Clone(cursorInitial))
+	if len(ss.Buckets()) < 2 {
+		t.Fatal("expected multiple buckets")
+	}
+
+	var forward []int
+	for c := ss.First(); c.Valid(); c.Next() {
+		if c.Index() != len(forward) {
+			t.Errorf("expected index %v, got %v", len(forward), c.Index())
+		}
+		forward = append(forward, c.Value())
+	}
+	assertEqualSlice(t, cursorInitial, forward)
+
+	var backward []int
+	for c := ss.Last(); c.Valid(); c.Prev() {
+		backward = append(backward, c.Value())
+	}
+	reversed := slices.Clone(cursorInitial)
+	slices.Reverse(reversed)
+	assertEqualSlice(t, reversed, backward)
+
+	// moving past an end and back
+	c := ss.Last()
+	if c.Next() {
+		t.Error("expected Next after the last element to return false")
+	}
+	if c.Index() != ss.Len() {
+		t.Errorf("expected index %v, got %v", ss.Len(), c.Index())
+	}
+	if !c.Prev() || c.Value() != 34 {
+		t.Error("expected Prev after the end to return the last element")
+	}
+
+	c = ss.First()
+	if c.Prev() {
+		t.Error("expected Prev before the first element to return false")
+	}
+	if c.Index() != -1 {
+		t.Errorf("expected index -1, got %v", c.Index())
+	}
+	if !c.Next() || c.Value() != 2 {
+		t.Error("expected Next before the start to return the first element")
+	}
+
+	empty := gosortedset.New([]int{})
+	if empty.First().Valid() || empty.Last().Valid() {
+		t.Error("expected cursors of an empty set to be invalid")
+	}
+}
+
+func TestCursorAt(t *testing.T) {
+	t.Parallel()
+
+	ss := gosortedset.New(slices.Clone(cursorInitial))
+	testCases := map[string]struct {
+		arg           int
+		expectedValid bool
+		expectedValue int
+		expectedError error
+	}{
+		"ok":           {arg: 9, expectedValid: true, expectedValue: 20},
+		"negative":     {arg: -1, expectedValid: true, expectedValue: 34},
+		"end":          {arg: 17, expectedValid: false},
+		"out of range": {arg: 18, expectedError: gosortedset.ErrIndexOutOfRange},
+		"negative out of range": {
+			arg:           -18,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := ss.At(testCase.arg)
+			if !errors.Is(err, testCase.expectedError) {
+				t.Fatalf("expected error %v, got %v", testCase.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if c.Valid() != testCase.expectedValid {
+				t.Fatalf("expected valid %v, got %v", testCase.expectedValid, c.Valid())
+			}
+			if c.Valid() && c.Value() != testCase.expectedValue {
+				t.Errorf("expected value %v, got %v", testCase.expectedValue, c.Value())
+			}
+		})
+	}
+}
+
+func TestCursorDelete(t *testing.T) {
+	t.Parallel()
+
+	initial := make([]int, 1000)
+	for i := range initial {
+		initial[i] = i
+	}
+	ss := gosortedset.New(slices.Clone(initial))
+
+	// delete every multiple of 3 while walking forward
+	var expected []int
+	for c := ss.First(); c.Valid(); {
+		v := c.Value()
+		if v%3 != 0 {
+			expected = append(expected, v)
+			c.Next()
+			continue
+		}
+		idx := c.Index()
+		more := c.Delete()
+		if more != c.Valid() {
+			t.Fatalf("Delete returned %v, but Valid is %v", more, c.Valid())
+		}
+		if c.Index() != idx {
+			t.Fatalf("expected index %v after Delete, got %v", idx, c.Index())
+		}
+		if more && c.Value() != v+1 {
+			t.Fatalf("expected %v after Delete, got %v", v+1, c.Value())
+		}
+	}
+	assertEqualSlice(t, expected, slices.Collect(ss.Values()))
+	if err := ss.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// delete everything from the end
+	c := ss.Last()
+	for c.Valid() {
+		if c.Delete() {
+			t.Fatal("expected no element after the last one")
+		}
+		c.Prev()
+	}
+	if ss.Len() != 0 {
+		t.Errorf("expected empty set, got %v", ss)
+	}
+}
+
+func TestCursorModified(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		operation func(ss *gosortedset.SortedSet[int])
+	}{
+		"add": {
+			operation: func(ss *gosortedset.SortedSet[int]) { ss.Add(3) },
+		},
+		"discard": {
+			operation: func(ss *gosortedset.SortedSet[int]) { ss.Discard(4) },
+		},
+		"pop": {
+			operation: func(ss *gosortedset.SortedSet[int]) { _, _ = ss.Pop(0) },
+		},
+		"delete range": {
+			operation: func(ss *gosortedset.SortedSet[int]) { ss.DeleteRange(10, 20) },
+		},
+		"add slice": {
+			operation: func(ss *gosortedset.SortedSet[int]) { ss.AddSlice([]int{1}) },
+		},
+		"other cursor": {
+			operation: func(ss *gosortedset.SortedSet[int]) { ss.First().Delete() },
+		},
+		"split": {
+			operation: func(ss *gosortedset.SortedSet[int]) { ss.SplitAt(10) },
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(slices.Clone(cursorInitial))
+			c := ss.Seek(10)
+			testCase.operation(ss)
+
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, gosortedset.ErrModified) {
+					t.Errorf("expected panic with ErrModified, got %v", err)
+				}
+			}()
+			c.Next()
+		})
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		ss := gosortedset.New(slices.Clone(cursorInitial))
+		c := ss.Seek(10)
+		ss.Add(10)
+		ss.Discard(11)
+		_ = ss.Snapshot()
+		if !c.Next() || c.Value() != 12 {
+			t.Error("expected the cursor to stay valid")
+		}
+	})
+}
@@ -7,6 +7,10 @@ import (
 
 var (
 	ErrIndexOutOfRange = errors.New("index out of range")
+	ErrEmpty           = errors.New("set is empty")
+	ErrOverlap         = errors.New("sets overlap")
+	ErrInvalidEncoding = errors.New("invalid encoding")
+	ErrModified        = errors.New("set was modified")
 )
 
 func Must[T cmp.Ordered](v T, err error) T {
@@ -3,3 +3,21 @@ package gosortedset
 func (ss *SortedSet[T]) Buckets() [][]T {
 	return ss.buckets
 }
+
+func (ss *SortedMultiset[T]) Buckets() [][]T {
+	return ss.buckets
+}
+
+func (sm *SortedMap[K, V]) Buckets() [][]K {
+	return sm.keys
+}
+
+func (ss *SortedSetFunc[T]) Buckets() [][]T {
+	return ss.buckets
+}
+
+// SetBuckets replaces the buckets of ss without any check, to build broken sets.
+func (ss *SortedSet[T]) SetBuckets(buckets [][]T, size int) {
+	ss.buckets, ss.size, ss.shared = buckets, size, nil
+	ss.recount()
+}
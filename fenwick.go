@@ -0,0 +1,50 @@
+package gosortedset
+
+import "math/bits"
+
+// fenwick is a Fenwick tree (binary indexed tree) over the lengths of the buckets.
+// It answers the number of elements before a bucket and finds the bucket of the idx-th element
+// in O(log(number of buckets)). f[0] is unused so that the tree is 1-indexed.
+type fenwick []int
+
+// build a tree over the lengths of buckets in O(len(buckets)).
+func newFenwick[T any](buckets [][]T) fenwick {
+	f := make(fenwick, len(buckets)+1)
+	for b, a := range buckets {
+		i := b + 1
+		f[i] += len(a)
+		if p := i + i&-i; p < len(f) {
+			f[p] += f[i]
+		}
+	}
+	return f
+}
+
+// add d to the length of bucket b.
+func (f fenwick) add(b, d int) {
+	for i := b + 1; i < len(f); i += i & -i {
+		f[i] += d
+	}
+}
+
+// return the total length of buckets[:b].
+func (f fenwick) prefix(b int) int {
+	sum := 0
+	for i := b; i > 0; i -= i & -i {
+		sum += f[i]
+	}
+	return sum
+}
+
+// return the index of the bucket and position of the idx-th element.
+// idx must be less than the total length.
+func (f fenwick) search(idx int) (int, int) {
+	b := 0
+	for step := 1 << (bits.Len(uint(len(f)-1)) - 1); step > 0; step >>= 1 {
+		if b+step < len(f) && f[b+step] <= idx {
+			b += step
+			idx -= f[b]
+		}
+	}
+	return b, idx
+}
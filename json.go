@@ -0,0 +1,23 @@
+package gosortedset
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// MarshalJSON encodes s as a JSON array in ascending order.
+func (s *SortedSet[T]) MarshalJSON() ([]byte, error) {
+	a := make([]T, 0, s.size)
+	a = slices.AppendSeq(a, s.Values())
+	return json.Marshal(a)
+}
+
+// UnmarshalJSON replaces the elements of s with a JSON array, which may be unsorted and contain duplicates.
+func (s *SortedSet[T]) UnmarshalJSON(data []byte) error {
+	var a []T
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	s.reset(sortUnique(a))
+	return nil
+}
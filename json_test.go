@@ -0,0 +1,125 @@
+package gosortedset_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial  []int
+		expected string
+	}{
+		"ok": {
+			initial:  []int{3, 1, 2},
+			expected: `[1,2,3]`,
+		},
+		"empty": {
+			initial:  []int{},
+			expected: `[]`,
+		},
+		"multiple buckets": {
+			initial:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			expected: `[1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17]`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(gosortedset.New(testCase.initial))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != testCase.expected {
+				t.Errorf("expected %v, got %v", testCase.expected, string(data))
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		data          string
+		expected      []string
+		expectedError bool
+	}{
+		"ok": {
+			data:     `["a","b","c"]`,
+			expected: []string{"a", "b", "c"},
+		},
+		"not sorted and duplicate": {
+			data:     `["c","a","b","a"]`,
+			expected: []string{"a", "b", "c"},
+		},
+		"empty": {
+			data:     `[]`,
+			expected: []string{},
+		},
+		"null": {
+			data:     `null`,
+			expected: []string{},
+		},
+		"not an array": {
+			data:          `{"a":1}`,
+			expectedError: true,
+		},
+		"wrong element type": {
+			data:          `[1,2]`,
+			expectedError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New([]string{"z"})
+			err := json.Unmarshal([]byte(testCase.data), ss)
+			if testCase.expectedError {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertEqualSlice(t, testCase.expected, slices.Collect(ss.Values()))
+			if ss.Len() != len(testCase.expected) {
+				t.Errorf("expected length %v, got %v", len(testCase.expected), ss.Len())
+			}
+		})
+	}
+}
+
+func TestJSONField(t *testing.T) {
+	t.Parallel()
+
+	type response struct {
+		IDs *gosortedset.SortedSet[int] `json:"ids"`
+	}
+
+	data, err := json.Marshal(response{IDs: gosortedset.New([]int{3, 1, 2})})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"ids":[1,2,3]}` {
+		t.Errorf("expected %v, got %v", `{"ids":[1,2,3]}`, string(data))
+	}
+
+	var decoded response
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertEqualSlice(t, []int{1, 2, 3}, slices.Collect(decoded.IDs.Values()))
+}
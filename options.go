@@ -0,0 +1,90 @@
+package gosortedset
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Option configures the bucket sizing of a SortedSet created by NewWithOptions.
+type Option func(*options)
+
+type options struct {
+	bucketRatio int
+	splitRatio  int
+	// negative means a quarter of bucketRatio times the number of buckets
+	mergeThreshold int
+	capacity       int
+}
+
+var defaultOptions = options{
+	bucketRatio:    bucketRatio,
+	splitRatio:     splitRatio,
+	mergeThreshold: -1,
+}
+
+// WithBucketRatio sets the average number of elements per bucket relative to the number of buckets:
+// a set of n elements is built with about √(n/r) buckets. It panics if r < 1.
+func WithBucketRatio(r int) Option {
+	if r < 1 {
+		panic(fmt.Sprintf("gosortedset: bucket ratio must be positive, got %d", r))
+	}
+	return func(o *options) { o.bucketRatio = r }
+}
+
+// WithSplitRatio sets when a bucket is split in two by Add:
+// a bucket is split once it holds more than r times the number of buckets. It panics if r < 1.
+func WithSplitRatio(r int) Option {
+	if r < 1 {
+		panic(fmt.Sprintf("gosortedset: split ratio must be positive, got %d", r))
+	}
+	return func(o *options) { o.splitRatio = r }
+}
+
+// WithMergeThreshold sets when a bucket which shrank by removal is merged into a neighbour:
+// a bucket is merged once it holds fewer than n elements, and n == 0 disables merging.
+// Empty buckets are always removed. It panics if n < 0.
+//
+// By default, a bucket is merged once it holds fewer than bucketRatio/4 times the number of buckets,
+// which is a quarter of the average bucket length of a set built by New. This keeps the number of buckets
+// within about twice of √(n/bucketRatio) however many elements are removed.
+func WithMergeThreshold(n int) Option {
+	if n < 0 {
+		panic(fmt.Sprintf("gosortedset: merge threshold must not be negative, got %d", n))
+	}
+	return func(o *options) { o.mergeThreshold = n }
+}
+
+// WithInitialCapacity reserves room for n elements, so that the set can grow to about n elements
+// with fewer reallocations. It panics if n < 0.
+func WithInitialCapacity(n int) Option {
+	if n < 0 {
+		panic(fmt.Sprintf("gosortedset: initial capacity must not be negative, got %d", n))
+	}
+	return func(o *options) { o.capacity = n }
+}
+
+// NewWithOptions is like New, but sizes the buckets according to opts.
+// The options are kept by the set and by the sets derived from it, such as snapshots and the halves of SplitAt.
+func NewWithOptions[T cmp.Ordered](a []T, opts ...Option) *SortedSet[T] {
+	o := defaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return fromSorted(sortUnique(a), &o)
+}
+
+// return the length below which a bucket is merged into a neighbour when there are numBucket buckets.
+func (o *options) mergeBound(numBucket int) int {
+	if o.mergeThreshold < 0 {
+		return numBucket * o.bucketRatio / 4
+	}
+	return o.mergeThreshold
+}
+
+// return the options of s, which are the defaults unless s was created by NewWithOptions.
+func (s *SortedSet[T]) options() *options {
+	if s.opts == nil {
+		return &defaultOptions
+	}
+	return s.opts
+}
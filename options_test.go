@@ -0,0 +1,158 @@
+package gosortedset_test
+
+import (
+	"slices"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       []int
+		options       []gosortedset.Option
+		operation     func(ss *gosortedset.SortedSet[int])
+		resultBuckets [][]int
+	}{
+		"default": {
+			initial:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			operation:     func(ss *gosortedset.SortedSet[int]) {},
+			resultBuckets: [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}},
+		},
+		"bucket ratio": {
+			initial:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			options:       []gosortedset.Option{gosortedset.WithBucketRatio(1)},
+			operation:     func(ss *gosortedset.SortedSet[int]) {},
+			resultBuckets: [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}, {13, 14, 15, 16}},
+		},
+		"split ratio": {
+			initial: []int{1, 2},
+			options: []gosortedset.Option{gosortedset.WithSplitRatio(2)},
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				for i := 3; i <= 6; i++ {
+					ss.Add(i)
+				}
+			},
+			resultBuckets: [][]int{{1}, {2, 3}, {4, 5, 6}},
+		},
+		"merge disabled": {
+			initial: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			options: []gosortedset.Option{gosortedset.WithBucketRatio(1), gosortedset.WithMergeThreshold(0)},
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				ss.Discard(5)
+			},
+			resultBuckets: [][]int{{1, 2, 3}, {4, 6}, {7, 8, 9}},
+		},
+		"merge threshold": {
+			initial: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			options: []gosortedset.Option{gosortedset.WithBucketRatio(1), gosortedset.WithMergeThreshold(3)},
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				ss.Discard(5)
+			},
+			resultBuckets: [][]int{{1, 2, 3}, {4, 6, 7, 8, 9}},
+		},
+		"merge threshold first bucket": {
+			initial: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			options: []gosortedset.Option{gosortedset.WithBucketRatio(1), gosortedset.WithMergeThreshold(3)},
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				_, _ = ss.Pop(0)
+			},
+			resultBuckets: [][]int{{2, 3, 4, 5, 6}, {7, 8, 9}},
+		},
+		"merge threshold last bucket": {
+			initial: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			options: []gosortedset.Option{gosortedset.WithBucketRatio(1), gosortedset.WithMergeThreshold(3)},
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				_, _ = ss.PopMax()
+			},
+			resultBuckets: [][]int{{1, 2, 3}, {4, 5, 6, 7, 8}},
+		},
+		"split ratio below bucket ratio": {
+			initial:       []int{1, 2, 3, 4, 5, 6, 7, 8},
+			options:       []gosortedset.Option{gosortedset.WithBucketRatio(4), gosortedset.WithSplitRatio(1)},
+			operation:     func(ss *gosortedset.SortedSet[int]) {},
+			resultBuckets: [][]int{{1, 2}, {3, 4, 5}, {6, 7, 8}},
+		},
+		"split half within bound": {
+			initial: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			options: []gosortedset.Option{gosortedset.WithBucketRatio(1), gosortedset.WithSplitRatio(2)},
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				left, _ := ss.SplitAt(4)
+				*ss = *left
+			},
+			resultBuckets: [][]int{{1}, {2, 3}},
+		},
+		"kept by snapshot": {
+			initial: []int{1, 2},
+			options: []gosortedset.Option{gosortedset.WithSplitRatio(2)},
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				*ss = *ss.Snapshot()
+				ss.Add(3)
+			},
+			resultBuckets: [][]int{{1}, {2, 3}},
+		},
+		"kept by split": {
+			initial: []int{1, 2},
+			options: []gosortedset.Option{gosortedset.WithSplitRatio(2)},
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				left, _ := ss.SplitAt(10)
+				left.Add(3)
+				*ss = *left
+			},
+			resultBuckets: [][]int{{1}, {2, 3}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.NewWithOptions(slices.Clone(testCase.initial), testCase.options...)
+			testCase.operation(ss)
+
+			assertEqualBuckets(t, testCase.resultBuckets, ss.Buckets())
+		})
+	}
+}
+
+func TestWithInitialCapacity(t *testing.T) {
+	t.Parallel()
+
+	ss := gosortedset.NewWithOptions([]int{1, 2, 3}, gosortedset.WithInitialCapacity(1000))
+	// one bucket can hold up to splitRatio+1 elements before it is split
+	if c := cap(ss.Buckets()[0]); c != 25 {
+		t.Errorf("expected capacity 25, got %v", c)
+	}
+
+	empty := gosortedset.NewWithOptions[int](nil, gosortedset.WithInitialCapacity(1000))
+	empty.Add(1)
+	if c := cap(empty.Buckets()[0]); c != 25 {
+		t.Errorf("expected capacity 25, got %v", c)
+	}
+}
+
+func TestOptionPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]func(){
+		"bucket ratio":     func() { gosortedset.WithBucketRatio(0) },
+		"split ratio":      func() { gosortedset.WithSplitRatio(-1) },
+		"merge threshold":  func() { gosortedset.WithMergeThreshold(-1) },
+		"initial capacity": func() { gosortedset.WithInitialCapacity(-1) },
+	}
+
+	for name, f := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			f()
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package gosortedset
+
+import "cmp"
+
+// walker reads the elements of buckets one by one in ascending order.
+type walker[T any] struct {
+	buckets [][]T
+	b, i    int
+}
+
+func (w *walker[T]) done() bool {
+	return w.b == len(w.buckets)
+}
+
+func (w *walker[T]) value() T {
+	return w.buckets[w.b][w.i]
+}
+
+func (w *walker[T]) next() {
+	w.i++
+	if w.i == len(w.buckets[w.b]) {
+		w.b++
+		w.i = 0
+	}
+}
+
+// walk a and b together in ascending order and call f for each distinct element
+// with whether it is contained in a and in b. The walk stops when f returns false.
+func merge[T cmp.Ordered](a, b *SortedSet[T], f func(x T, inA, inB bool) bool) {
+	wa, wb, ok := mergeOverlap(a, b, f)
+	if !ok {
+		return
+	}
+	for ; !wa.done(); wa.next() {
+		if !f(wa.value(), true, false) {
+			return
+		}
+	}
+	for ; !wb.done(); wb.next() {
+		if !f(wb.value(), false, true) {
+			return
+		}
+	}
+}
+
+// the same as merge, but stop as soon as either a or b runs out of elements.
+// It returns the walkers at the position where it stopped and false if f stopped the walk.
+func mergeOverlap[T cmp.Ordered](a, b *SortedSet[T], f func(x T, inA, inB bool) bool) (*walker[T], *walker[T], bool) {
+	wa, wb := &walker[T]{buckets: a.buckets}, &walker[T]{buckets: b.buckets}
+	for !wa.done() && !wb.done() {
+		x, y := wa.value(), wb.value()
+		var ok bool
+		switch {
+		case x < y:
+			ok = f(x, true, false)
+			wa.next()
+		case x > y:
+			ok = f(y, false, true)
+			wb.next()
+		default:
+			ok = f(x, true, true)
+			wa.next()
+			wb.next()
+		}
+		if !ok {
+			return wa, wb, false
+		}
+	}
+	return wa, wb, true
+}
+
+// collect the elements for which keep returns true while merging a and b.
+func mergeSlice[T cmp.Ordered](a, b *SortedSet[T], capacity int, keep func(inA, inB bool) bool) []T {
+	ans := make([]T, 0, capacity)
+	merge(a, b, func(x T, inA, inB bool) bool {
+		if keep(inA, inB) {
+			ans = append(ans, x)
+		}
+		return true
+	})
+	return ans
+}
+
+func keepUnion(inA, inB bool) bool               { return true }
+func keepIntersection(inA, inB bool) bool        { return inA && inB }
+func keepDifference(inA, inB bool) bool          { return inA && !inB }
+func keepSymmetricDifference(inA, inB bool) bool { return inA != inB }
+
+// Union returns a new set with the elements contained in a or b.
+func Union[T cmp.Ordered](a, b *SortedSet[T]) *SortedSet[T] {
+	return fromSorted(mergeSlice(a, b, a.size+b.size, keepUnion), a.opts)
+}
+
+// Intersection returns a new set with the elements contained in both a and b.
+func Intersection[T cmp.Ordered](a, b *SortedSet[T]) *SortedSet[T] {
+	return fromSorted(mergeSlice(a, b, min(a.size, b.size), keepIntersection), a.opts)
+}
+
+// Difference returns a new set with the elements contained in a but not in b.
+func Difference[T cmp.Ordered](a, b *SortedSet[T]) *SortedSet[T] {
+	return fromSorted(mergeSlice(a, b, a.size, keepDifference), a.opts)
+}
+
+// SymmetricDifference returns a new set with the elements contained in exactly one of a and b.
+func SymmetricDifference[T cmp.Ordered](a, b *SortedSet[T]) *SortedSet[T] {
+	return fromSorted(mergeSlice(a, b, a.size+b.size, keepSymmetricDifference), a.opts)
+}
+
+// UnionWith adds all the elements of other to s.
+func (s *SortedSet[T]) UnionWith(other *SortedSet[T]) {
+	s.reset(mergeSlice(s, other, s.size+other.size, keepUnion))
+}
+
+// IntersectWith removes the elements of s which are not contained in other.
+func (s *SortedSet[T]) IntersectWith(other *SortedSet[T]) {
+	s.reset(mergeSlice(s, other, min(s.size, other.size), keepIntersection))
+}
+
+// DifferenceWith removes the elements of other from s.
+func (s *SortedSet[T]) DifferenceWith(other *SortedSet[T]) {
+	s.reset(mergeSlice(s, other, s.size, keepDifference))
+}
+
+// SymmetricDifferenceWith keeps only the elements contained in exactly one of s and other.
+func (s *SortedSet[T]) SymmetricDifferenceWith(other *SortedSet[T]) {
+	s.reset(mergeSlice(s, other, s.size+other.size, keepSymmetricDifference))
+}
+
+// IsSubsetOf reports whether every element of s is contained in other.
+func (s *SortedSet[T]) IsSubsetOf(other *SortedSet[T]) bool {
+	if s.size > other.size {
+		return false
+	}
+	wa, _, ok := mergeOverlap(s, other, func(_ T, inA, inB bool) bool {
+		return !inA || inB
+	})
+	return ok && wa.done()
+}
+
+// IsSupersetOf reports whether every element of other is contained in s.
+func (s *SortedSet[T]) IsSupersetOf(other *SortedSet[T]) bool {
+	return other.IsSubsetOf(s)
+}
+
+// IsDisjoint reports whether s and other have no elements in common.
+func (s *SortedSet[T]) IsDisjoint(other *SortedSet[T]) bool {
+	_, _, ok := mergeOverlap(s, other, func(_ T, inA, inB bool) bool {
+		return !inA || !inB
+	})
+	return ok
+}
+
+// IntersectionLen returns the number of elements contained in both s and other
+// without building the intersection.
+func (s *SortedSet[T]) IntersectionLen(other *SortedSet[T]) int {
+	n := 0
+	mergeOverlap(s, other, func(_ T, inA, inB bool) bool {
+		if inA && inB {
+			n++
+		}
+		return true
+	})
+	return n
+}
@@ -0,0 +1,242 @@
+package gosortedset_test
+
+import (
+	"slices"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestSetOperation(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b                        []int
+		union                       []int
+		intersection                []int
+		difference                  []int
+		symmetricDifference         []int
+		expectedUnionBuckets        [][]int
+		expectedIntersectionBuckets [][]int
+	}{
+		"ok": {
+			a:                           []int{1, 2, 3, 4},
+			b:                           []int{3, 4, 5, 6},
+			union:                       []int{1, 2, 3, 4, 5, 6},
+			intersection:                []int{3, 4},
+			difference:                  []int{1, 2},
+			symmetricDifference:         []int{1, 2, 5, 6},
+			expectedUnionBuckets:        [][]int{{1, 2, 3, 4, 5, 6}},
+			expectedIntersectionBuckets: [][]int{{3, 4}},
+		},
+		"disjoint": {
+			a:                           []int{1, 3, 5},
+			b:                           []int{2, 4, 6},
+			union:                       []int{1, 2, 3, 4, 5, 6},
+			intersection:                []int{},
+			difference:                  []int{1, 3, 5},
+			symmetricDifference:         []int{1, 2, 3, 4, 5, 6},
+			expectedUnionBuckets:        [][]int{{1, 2, 3, 4, 5, 6}},
+			expectedIntersectionBuckets: [][]int{},
+		},
+		"empty": {
+			a:                           []int{},
+			b:                           []int{1, 2},
+			union:                       []int{1, 2},
+			intersection:                []int{},
+			difference:                  []int{},
+			symmetricDifference:         []int{1, 2},
+			expectedUnionBuckets:        [][]int{{1, 2}},
+			expectedIntersectionBuckets: [][]int{},
+		},
+		"multiple buckets": {
+			a:                           []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			b:                           []int{15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+			union:                       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+			intersection:                []int{15, 16, 17},
+			difference:                  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14},
+			symmetricDifference:         []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+			expectedUnionBuckets:        [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, {16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}},
+			expectedIntersectionBuckets: [][]int{{15, 16, 17}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := gosortedset.New(testCase.a), gosortedset.New(testCase.b)
+
+			union := gosortedset.Union(a, b)
+			assertEqualSlice(t, testCase.union, slices.Collect(union.Values()))
+			assertEqualBuckets(t, testCase.expectedUnionBuckets, union.Buckets())
+			intersection := gosortedset.Intersection(a, b)
+			assertEqualSlice(t, testCase.intersection, slices.Collect(intersection.Values()))
+			assertEqualBuckets(t, testCase.expectedIntersectionBuckets, intersection.Buckets())
+			assertEqualSlice(t, testCase.difference, slices.Collect(gosortedset.Difference(a, b).Values()))
+			assertEqualSlice(t, testCase.symmetricDifference, slices.Collect(gosortedset.SymmetricDifference(a, b).Values()))
+
+			// the operands must be left untouched
+			assertEqualSlice(t, testCase.a, slices.Collect(a.Values()))
+			assertEqualSlice(t, testCase.b, slices.Collect(b.Values()))
+		})
+	}
+}
+
+func TestSetOperationInPlace(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial   []int
+		other     []int
+		operation func(s, other *gosortedset.SortedSet[int])
+		expected  []int
+	}{
+		"union": {
+			initial:   []int{1, 3, 5},
+			other:     []int{2, 3, 4},
+			operation: (*gosortedset.SortedSet[int]).UnionWith,
+			expected:  []int{1, 2, 3, 4, 5},
+		},
+		"intersection": {
+			initial:   []int{1, 3, 5},
+			other:     []int{2, 3, 4},
+			operation: (*gosortedset.SortedSet[int]).IntersectWith,
+			expected:  []int{3},
+		},
+		"difference": {
+			initial:   []int{1, 3, 5},
+			other:     []int{2, 3, 4},
+			operation: (*gosortedset.SortedSet[int]).DifferenceWith,
+			expected:  []int{1, 5},
+		},
+		"symmetric difference": {
+			initial:   []int{1, 3, 5},
+			other:     []int{2, 3, 4},
+			operation: (*gosortedset.SortedSet[int]).SymmetricDifferenceWith,
+			expected:  []int{1, 2, 4, 5},
+		},
+		"intersection to empty": {
+			initial:   []int{1, 3, 5},
+			other:     []int{},
+			operation: (*gosortedset.SortedSet[int]).IntersectWith,
+			expected:  []int{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			testCase.operation(ss, gosortedset.New(testCase.other))
+			assertEqualSlice(t, testCase.expected, slices.Collect(ss.Values()))
+			if ss.Len() != len(testCase.expected) {
+				t.Errorf("expected length %v, got %v", len(testCase.expected), ss.Len())
+			}
+
+			// the set must stay usable after being rebuilt
+			ss.Add(100)
+			if !ss.Contains(100) {
+				t.Errorf("expected 100 to be added")
+			}
+		})
+	}
+}
+
+func TestSetPredicate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b                    []int
+		expectedSubset          bool
+		expectedSuperset        bool
+		expectedDisjoint        bool
+		expectedIntersectionLen int
+	}{
+		"subset": {
+			a:                       []int{2, 3},
+			b:                       []int{1, 2, 3, 4},
+			expectedSubset:          true,
+			expectedSuperset:        false,
+			expectedDisjoint:        false,
+			expectedIntersectionLen: 2,
+		},
+		"superset": {
+			a:                       []int{1, 2, 3, 4},
+			b:                       []int{1, 4},
+			expectedSubset:          false,
+			expectedSuperset:        true,
+			expectedDisjoint:        false,
+			expectedIntersectionLen: 2,
+		},
+		"equal": {
+			a:                       []int{1, 2, 3},
+			b:                       []int{1, 2, 3},
+			expectedSubset:          true,
+			expectedSuperset:        true,
+			expectedDisjoint:        false,
+			expectedIntersectionLen: 3,
+		},
+		"overlap": {
+			a:                       []int{1, 2, 3},
+			b:                       []int{3, 4, 5},
+			expectedSubset:          false,
+			expectedSuperset:        false,
+			expectedDisjoint:        false,
+			expectedIntersectionLen: 1,
+		},
+		"larger element left": {
+			a:                       []int{1, 2, 5},
+			b:                       []int{1, 2, 3, 4},
+			expectedSubset:          false,
+			expectedSuperset:        false,
+			expectedDisjoint:        false,
+			expectedIntersectionLen: 2,
+		},
+		"disjoint": {
+			a:                       []int{1, 3, 5},
+			b:                       []int{2, 4, 6},
+			expectedSubset:          false,
+			expectedSuperset:        false,
+			expectedDisjoint:        true,
+			expectedIntersectionLen: 0,
+		},
+		"empty": {
+			a:                       []int{},
+			b:                       []int{1, 2},
+			expectedSubset:          true,
+			expectedSuperset:        false,
+			expectedDisjoint:        true,
+			expectedIntersectionLen: 0,
+		},
+		"multiple buckets": {
+			a:                       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			b:                       []int{9, 17},
+			expectedSubset:          false,
+			expectedSuperset:        true,
+			expectedDisjoint:        false,
+			expectedIntersectionLen: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := gosortedset.New(testCase.a), gosortedset.New(testCase.b)
+			if result := a.IsSubsetOf(b); result != testCase.expectedSubset {
+				t.Errorf("IsSubsetOf: expected %v, got %v", testCase.expectedSubset, result)
+			}
+			if result := a.IsSupersetOf(b); result != testCase.expectedSuperset {
+				t.Errorf("IsSupersetOf: expected %v, got %v", testCase.expectedSuperset, result)
+			}
+			if result := a.IsDisjoint(b); result != testCase.expectedDisjoint {
+				t.Errorf("IsDisjoint: expected %v, got %v", testCase.expectedDisjoint, result)
+			}
+			if result := a.IntersectionLen(b); result != testCase.expectedIntersectionLen {
+				t.Errorf("IntersectionLen: expected %v, got %v", testCase.expectedIntersectionLen, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package gosortedset
+
+import "slices"
+
+// Snapshot returns a set with the same elements as s which is not affected by later changes to s, and vice versa.
+// The buckets are shared between them and copied only when either side modifies one,
+// so taking a snapshot costs O(number of buckets).
+func (s *SortedSet[T]) Snapshot() *SortedSet[T] {
+	s.shareAll()
+	return &SortedSet[T]{
+		buckets: slices.Clone(s.buckets),
+		size:    s.size,
+		shared:  slices.Clone(s.shared),
+		sizes:   slices.Clone(s.sizes),
+		opts:    s.opts,
+	}
+}
+
+// mark every bucket as shared with a snapshot.
+// Marking a bucket which is not actually shared only costs an extra copy.
+func (s *SortedSet[T]) shareAll() {
+	s.shared = make([]bool, len(s.buckets))
+	for b := range s.shared {
+		s.shared[b] = true
+	}
+}
+
+// copy buckets[b] if it is shared with a snapshot, so that it can be modified in place.
+func (s *SortedSet[T]) own(b int) {
+	if s.shared != nil && s.shared[b] {
+		s.buckets[b] = slices.Clone(s.buckets[b])
+		s.shared[b] = false
+	}
+}
+
+// keep shared in line with buckets after buckets[i:j] were deleted.
+func (s *SortedSet[T]) deleteShared(i, j int) {
+	if s.shared != nil {
+		s.shared = slices.Delete(s.shared, i, j)[:len(s.buckets)]
+	}
+}
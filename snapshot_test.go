@@ -0,0 +1,108 @@
+package gosortedset_test
+
+import (
+	"slices"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	initial := func() []int {
+		a := make([]int, 0, 50)
+		for i := 1; i <= 50; i++ {
+			a = append(a, i*2)
+		}
+		return a
+	}
+
+	testCases := map[string]struct {
+		operation func(ss *gosortedset.SortedSet[int])
+	}{
+		"add": {
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				for i := 1; i <= 101; i += 2 {
+					ss.Add(i)
+				}
+			},
+		},
+		"discard": {
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				for i := 2; i <= 100; i += 4 {
+					ss.Discard(i)
+				}
+			},
+		},
+		"pop": {
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				for range 30 {
+					_, _ = ss.Pop(-1)
+					_, _ = ss.Pop(0)
+				}
+			},
+		},
+		"delete range": {
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				ss.DeleteRange(11, 21)
+				ss.DeleteRange(41, 71)
+				ss.Add(15)
+				ss.Add(43)
+			},
+		},
+		"add slice": {
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				ss.AddSlice([]int{1, 3, 5, 7})
+			},
+		},
+		"split": {
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				left, right := ss.SplitAt(51)
+				left.Add(49)
+				right.Add(53)
+				_, _ = left.Pop(0)
+				_, _ = right.Pop(-1)
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(initial())
+			snapshot := ss.Snapshot()
+			if &ss.Buckets()[0][0] != &snapshot.Buckets()[0][0] {
+				t.Errorf("expected the snapshot to share the buckets")
+			}
+
+			testCase.operation(ss)
+			assertEqualSlice(t, initial(), slices.Collect(snapshot.Values()))
+			if snapshot.Len() != len(initial()) {
+				t.Errorf("expected length %v, got %v", len(initial()), snapshot.Len())
+			}
+
+			// modifying the snapshot must not affect the original either
+			expected := slices.Collect(ss.Values())
+			copied := ss.Snapshot()
+			testCase.operation(copied)
+			assertEqualSlice(t, expected, slices.Collect(ss.Values()))
+		})
+	}
+}
+
+func TestSnapshotOfSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ss := gosortedset.New([]int{1, 2, 3})
+	first := ss.Snapshot()
+	ss.Add(4)
+	second := first.Snapshot()
+	first.Discard(1)
+	second.Add(0)
+
+	assertEqualSlice(t, []int{1, 2, 3, 4}, slices.Collect(ss.Values()))
+	assertEqualSlice(t, []int{2, 3}, slices.Collect(first.Values()))
+	assertEqualSlice(t, []int{0, 1, 2, 3}, slices.Collect(second.Values()))
+}
@@ -0,0 +1,306 @@
+package gosortedset
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SortedMap keeps its keys in the same bucket layout as SortedSet.
+// vals[i][j] is the value associated with keys[i][j].
+type SortedMap[K cmp.Ordered, V any] struct {
+	keys [][]K
+	vals [][]V
+	size int
+}
+
+func NewMap[K cmp.Ordered, V any](m map[K]V) *SortedMap[K, V] {
+	s := &SortedMap[K, V]{}
+	keys := slices.Sorted(maps.Keys(m))
+	vals := make([]V, len(keys))
+	for i, k := range keys {
+		vals[i] = m[k]
+	}
+
+	s.size = len(keys)
+	s.keys = bucketize(keys, &defaultOptions)
+	s.vals = bucketize(vals, &defaultOptions)
+
+	return s
+}
+
+func (s *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i, bucket := range s.keys {
+			for j, k := range bucket {
+				if !yield(k, s.vals[i][j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *SortedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, bucket := range s.keys {
+			for _, k := range bucket {
+				if !yield(k) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *SortedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, bucket := range s.vals {
+			for _, v := range bucket {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *SortedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := len(s.keys) - 1; i >= 0; i-- {
+			for j := len(s.keys[i]) - 1; j >= 0; j-- {
+				if !yield(s.keys[i][j], s.vals[i][j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *SortedMap[K, V]) Len() int {
+	return s.size
+}
+
+func (s *SortedMap[K, V]) String() string {
+	sb := &strings.Builder{}
+	_, _ = sb.WriteString("SortedMap{")
+
+	for k, v := range s.All() {
+		_, _ = sb.WriteString(fmt.Sprintf("%v: %v, ", k, v))
+	}
+
+	_, _ = sb.WriteString("}")
+
+	return sb.String()
+}
+
+// return the index of the bucket and position in which k should be.
+func (s *SortedMap[K, V]) position(k K) (int, int) {
+	var bucket int
+	for bucket = range s.keys {
+		if k <= s.keys[bucket][len(s.keys[bucket])-1] {
+			break
+		}
+	}
+	i, _ := slices.BinarySearch(s.keys[bucket], k)
+	return bucket, i
+}
+
+// return the index of the bucket and position of k, or false if k is not in the map.
+func (s *SortedMap[K, V]) find(k K) (int, int, bool) {
+	if s.size == 0 {
+		return 0, 0, false
+	}
+	b, i := s.position(k)
+	return b, i, i < len(s.keys[b]) && s.keys[b][i] == k
+}
+
+func (s *SortedMap[K, V]) Contains(k K) bool {
+	_, _, ok := s.find(k)
+	return ok
+}
+
+func (s *SortedMap[K, V]) Get(k K) (V, bool) {
+	b, i, ok := s.find(k)
+	if !ok {
+		var v V
+		return v, false
+	}
+	return s.vals[b][i], true
+}
+
+// Set associates v with k. It returns true if k was not in the map.
+func (s *SortedMap[K, V]) Set(k K, v V) bool {
+	if s.size == 0 {
+		s.keys = [][]K{{k}}
+		s.vals = [][]V{{v}}
+		s.size = 1
+		return true
+	}
+	b, i := s.position(k)
+	if i != len(s.keys[b]) && s.keys[b][i] == k {
+		s.vals[b][i] = v
+		return false
+	}
+	s.keys[b] = slices.Insert(s.keys[b], i, k)
+	s.vals[b] = slices.Insert(s.vals[b], i, v)
+	s.size++
+
+	s.keys = splitBucket(s.keys, b, &defaultOptions)
+	s.vals = splitBucket(s.vals, b, &defaultOptions)
+	return true
+}
+
+func (s *SortedMap[K, V]) pop(b int, i int) (K, V) {
+	k, v := s.keys[b][i], s.vals[b][i]
+	s.keys[b] = slices.Delete(s.keys[b], i, i+1)
+	s.vals[b] = slices.Delete(s.vals[b], i, i+1)
+	s.size--
+	if len(s.keys[b]) == 0 {
+		s.keys = slices.Delete(s.keys, b, b+1)
+		s.vals = slices.Delete(s.vals, b, b+1)
+	}
+	return k, v
+}
+
+func (s *SortedMap[K, V]) Delete(k K) bool {
+	b, i, ok := s.find(k)
+	if !ok {
+		return false
+	}
+	_, _ = s.pop(b, i)
+
+	return true
+}
+
+func (s *SortedMap[K, V]) Lt(k K) (K, V, bool) {
+	for b := len(s.keys) - 1; b >= 0; b-- {
+		a := s.keys[b]
+		if a[0] < k {
+			j, _ := slices.BinarySearch(a, k)
+			return a[j-1], s.vals[b][j-1], true
+		}
+	}
+	var key K
+	var v V
+	return key, v, false
+}
+
+func (s *SortedMap[K, V]) Le(k K) (K, V, bool) {
+	for b := len(s.keys) - 1; b >= 0; b-- {
+		a := s.keys[b]
+		if a[0] <= k {
+			j, ok := slices.BinarySearch(a, k)
+			if !ok {
+				j--
+			}
+			return a[j], s.vals[b][j], true
+		}
+	}
+	var key K
+	var v V
+	return key, v, false
+}
+
+func (s *SortedMap[K, V]) Gt(k K) (K, V, bool) {
+	for b, a := range s.keys {
+		if a[len(a)-1] > k {
+			j, ok := slices.BinarySearch(a, k)
+			if ok {
+				j++
+			}
+			return a[j], s.vals[b][j], true
+		}
+	}
+	var key K
+	var v V
+	return key, v, false
+}
+
+func (s *SortedMap[K, V]) Ge(k K) (K, V, bool) {
+	for b, a := range s.keys {
+		if a[len(a)-1] >= k {
+			j, _ := slices.BinarySearch(a, k)
+			return a[j], s.vals[b][j], true
+		}
+	}
+	var key K
+	var v V
+	return key, v, false
+}
+
+func (s *SortedMap[K, V]) GetItem(idx int) (K, V, error) {
+	if idx < 0 {
+		for b := len(s.keys) - 1; b >= 0; b-- {
+			idx += len(s.keys[b])
+			if idx >= 0 {
+				return s.keys[b][idx], s.vals[b][idx], nil
+			}
+		}
+	} else {
+		for b, a := range s.keys {
+			if idx < len(a) {
+				return a[idx], s.vals[b][idx], nil
+			}
+			idx -= len(a)
+		}
+	}
+
+	var k K
+	var v V
+	return k, v, ErrIndexOutOfRange
+}
+
+func (s *SortedMap[K, V]) Pop(idx int) (K, V, error) {
+	if idx < 0 {
+		for b := len(s.keys) - 1; b >= 0; b-- {
+			idx += len(s.keys[b])
+			if idx >= 0 {
+				k, v := s.pop(b, idx)
+				return k, v, nil
+			}
+		}
+	} else {
+		for b := range s.keys {
+			if idx < len(s.keys[b]) {
+				k, v := s.pop(b, idx)
+				return k, v, nil
+			}
+			idx -= len(s.keys[b])
+		}
+	}
+
+	var k K
+	var v V
+	return k, v, ErrIndexOutOfRange
+}
+
+func (s *SortedMap[K, V]) Index(k K) int {
+	ans := 0
+	for _, a := range s.keys {
+		if a[len(a)-1] >= k {
+			i, _ := slices.BinarySearch(a, k)
+			return ans + i
+		}
+		ans += len(a)
+	}
+	return ans
+}
+
+func (s *SortedMap[K, V]) IndexRight(k K) int {
+	ans := 0
+	for _, a := range s.keys {
+		if a[len(a)-1] >= k {
+			i, ok := slices.BinarySearch(a, k)
+			if !ok {
+				return ans + i
+			}
+			return ans + i + 1
+		}
+		ans += len(a)
+	}
+	return ans
+}
@@ -0,0 +1,291 @@
+package gosortedset_test
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestNewMap(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial        map[int]string
+		expectedKeys   []int
+		expectedValues []string
+	}{
+		"ok": {
+			initial:        map[int]string{3: "c", 1: "a", 2: "b"},
+			expectedKeys:   []int{1, 2, 3},
+			expectedValues: []string{"a", "b", "c"},
+		},
+		"nil": {
+			initial:        nil,
+			expectedKeys:   []int{},
+			expectedValues: []string{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sm := gosortedset.NewMap(testCase.initial)
+			assertEqualSlice(t, testCase.expectedKeys, slices.Collect(sm.Keys()))
+			assertEqualSlice(t, testCase.expectedValues, slices.Collect(sm.Values()))
+			if sm.Len() != len(testCase.expectedKeys) {
+				t.Errorf("expected %v, got %v", len(testCase.expectedKeys), sm.Len())
+			}
+		})
+	}
+}
+
+func TestMapSet(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial         map[int]int
+		operation       func(sm *gosortedset.SortedMap[int, int])
+		expected        map[int]int
+		expectedBuckets [][]int
+	}{
+		"ok": {
+			initial: map[int]int{1: 10, 3: 30},
+			operation: func(sm *gosortedset.SortedMap[int, int]) {
+				sm.Set(2, 20)
+			},
+			expected:        map[int]int{1: 10, 2: 20, 3: 30},
+			expectedBuckets: [][]int{{1, 2, 3}},
+		},
+		"overwrite": {
+			initial: map[int]int{1: 10, 3: 30},
+			operation: func(sm *gosortedset.SortedMap[int, int]) {
+				sm.Set(3, 300)
+			},
+			expected:        map[int]int{1: 10, 3: 300},
+			expectedBuckets: [][]int{{1, 3}},
+		},
+		"set to empty": {
+			initial: map[int]int{},
+			operation: func(sm *gosortedset.SortedMap[int, int]) {
+				sm.Set(1, 10)
+			},
+			expected:        map[int]int{1: 10},
+			expectedBuckets: [][]int{{1}},
+		},
+		"set and split": {
+			initial: map[int]int{},
+			operation: func(sm *gosortedset.SortedMap[int, int]) {
+				for i := 25; i >= 1; i-- {
+					sm.Set(i, i*10)
+				}
+			},
+			expected: func() map[int]int {
+				m := make(map[int]int)
+				for i := 1; i <= 25; i++ {
+					m[i] = i * 10
+				}
+				return m
+			}(),
+			expectedBuckets: [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, {13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sm := gosortedset.NewMap(testCase.initial)
+			testCase.operation(sm)
+			if actual := maps.Collect(sm.All()); !maps.Equal(testCase.expected, actual) {
+				t.Errorf("expected %v, got %v", testCase.expected, actual)
+			}
+			for k, v := range testCase.expected {
+				if actual, ok := sm.Get(k); !ok || actual != v {
+					t.Errorf("Get(%v): expected %v, true, got %v, %v", k, v, actual, ok)
+				}
+			}
+			assertEqualBuckets(t, testCase.expectedBuckets, sm.Buckets())
+		})
+	}
+}
+
+func TestMapDelete(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial  map[int]int
+		arg      int
+		expected bool
+		result   []int
+	}{
+		"ok": {
+			initial:  map[int]int{1: 10, 2: 20, 3: 30},
+			arg:      2,
+			expected: true,
+			result:   []int{1, 3},
+		},
+		"not contains": {
+			initial:  map[int]int{1: 10, 3: 30},
+			arg:      2,
+			expected: false,
+			result:   []int{1, 3},
+		},
+		"empty": {
+			initial:  map[int]int{},
+			arg:      1,
+			expected: false,
+			result:   []int{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sm := gosortedset.NewMap(testCase.initial)
+			if result := sm.Delete(testCase.arg); result != testCase.expected {
+				t.Errorf("expected %v, got %v", testCase.expected, result)
+			}
+			if _, ok := sm.Get(testCase.arg); ok {
+				t.Errorf("expected %v to be deleted", testCase.arg)
+			}
+			assertEqualSlice(t, testCase.result, slices.Collect(sm.Keys()))
+		})
+	}
+}
+
+func TestMapBounds(t *testing.T) {
+	t.Parallel()
+
+	type entry struct {
+		key   int
+		value string
+		exist bool
+	}
+
+	testCases := map[string]struct {
+		initial map[int]string
+		arg     int
+		lt      entry
+		le      entry
+		gt      entry
+		ge      entry
+	}{
+		"contains": {
+			initial: map[int]string{1: "a", 3: "c", 5: "e"},
+			arg:     3,
+			lt:      entry{1, "a", true},
+			le:      entry{3, "c", true},
+			gt:      entry{5, "e", true},
+			ge:      entry{3, "c", true},
+		},
+		"not contains": {
+			initial: map[int]string{1: "a", 5: "e"},
+			arg:     3,
+			lt:      entry{1, "a", true},
+			le:      entry{1, "a", true},
+			gt:      entry{5, "e", true},
+			ge:      entry{5, "e", true},
+		},
+		"out of keys": {
+			initial: map[int]string{1: "a"},
+			arg:     1,
+			le:      entry{1, "a", true},
+			ge:      entry{1, "a", true},
+		},
+		"empty": {
+			initial: map[int]string{},
+			arg:     1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sm := gosortedset.NewMap(testCase.initial)
+			check := func(op string, expected entry, key int, value string, ok bool) {
+				t.Helper()
+				if (entry{key, value, ok}) != expected {
+					t.Errorf("%s: expected %v, got %v", op, expected, entry{key, value, ok})
+				}
+			}
+			key, value, ok := sm.Lt(testCase.arg)
+			check("Lt", testCase.lt, key, value, ok)
+			key, value, ok = sm.Le(testCase.arg)
+			check("Le", testCase.le, key, value, ok)
+			key, value, ok = sm.Gt(testCase.arg)
+			check("Gt", testCase.gt, key, value, ok)
+			key, value, ok = sm.Ge(testCase.arg)
+			check("Ge", testCase.ge, key, value, ok)
+		})
+	}
+}
+
+func TestMapGetItem(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       map[int]string
+		arg           int
+		expectedKey   int
+		expectedValue string
+		expectedError error
+	}{
+		"ok": {
+			initial:       map[int]string{1: "a", 2: "b", 3: "c"},
+			arg:           1,
+			expectedKey:   2,
+			expectedValue: "b",
+		},
+		"negative index": {
+			initial:       map[int]string{1: "a", 2: "b", 3: "c"},
+			arg:           -1,
+			expectedKey:   3,
+			expectedValue: "c",
+		},
+		"index out of range": {
+			initial:       map[int]string{1: "a", 2: "b", 3: "c"},
+			arg:           3,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+		"empty": {
+			initial:       map[int]string{},
+			arg:           0,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sm := gosortedset.NewMap(testCase.initial)
+			key, value, err := sm.GetItem(testCase.arg)
+			if testCase.expectedError != nil {
+				if !errors.Is(err, testCase.expectedError) {
+					t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+				}
+				return
+			}
+			if key != testCase.expectedKey || value != testCase.expectedValue {
+				t.Errorf("expected %v, %v, got %v, %v", testCase.expectedKey, testCase.expectedValue, key, value)
+			}
+
+			key, value, err = sm.Pop(testCase.arg)
+			if err != nil || key != testCase.expectedKey || value != testCase.expectedValue {
+				t.Errorf("Pop: expected %v, %v, got %v, %v, %v", testCase.expectedKey, testCase.expectedValue, key, value, err)
+			}
+			if sm.Contains(key) {
+				t.Errorf("expected %v to be popped", key)
+			}
+			if index := sm.Index(testCase.expectedKey); index != sm.IndexRight(testCase.expectedKey) {
+				t.Errorf("expected Index and IndexRight to agree after Pop, got %v, %v", index, sm.IndexRight(testCase.expectedKey))
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package gosortedset
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+type SortedMultiset[T cmp.Ordered] struct {
+	buckets [][]T
+	size    int
+}
+
+func NewMultiset[T cmp.Ordered](a []T) *SortedMultiset[T] {
+	s := &SortedMultiset[T]{}
+	if !slices.IsSorted(a) {
+		slices.Sort(a)
+	}
+
+	s.size = len(a)
+	s.buckets = bucketize(a, &defaultOptions)
+
+	return s
+}
+
+func (s *SortedMultiset[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		idx := 0
+		for _, bucket := range s.buckets {
+			for _, v := range bucket {
+				if !yield(idx, v) {
+					return
+				}
+				idx++
+			}
+		}
+	}
+}
+
+func (s *SortedMultiset[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, bucket := range s.buckets {
+			for _, v := range bucket {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *SortedMultiset[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		idx := s.size - 1
+		for i := len(s.buckets) - 1; i >= 0; i-- {
+			a := s.buckets[i]
+			for j := len(a) - 1; j >= 0; j-- {
+				if !yield(idx, a[j]) {
+					return
+				}
+				idx--
+			}
+		}
+	}
+}
+
+func (s *SortedMultiset[T]) Len() int {
+	return s.size
+}
+
+func (s *SortedMultiset[T]) Equals(other *SortedMultiset[T]) bool {
+	if s.size != other.size {
+		return false
+	}
+	wa, wb := &walker[T]{buckets: s.buckets}, &walker[T]{buckets: other.buckets}
+	for ; !wa.done(); wa.next() {
+		if wa.value() != wb.value() {
+			return false
+		}
+		wb.next()
+	}
+	return true
+}
+
+func (s *SortedMultiset[T]) String() string {
+	sb := &strings.Builder{}
+	_, _ = sb.WriteString("SortedMultiset{")
+
+	for i := range s.buckets {
+		for j := range s.buckets[i] {
+			_, _ = sb.WriteString(fmt.Sprintf("%v, ", s.buckets[i][j]))
+		}
+	}
+
+	_, _ = sb.WriteString("}")
+
+	return sb.String()
+}
+
+// return the bucket, index of the bucket and position in which x should be.
+func (s *SortedMultiset[T]) position(x T) (*[]T, int, int) {
+	var bucket int
+	var a *[]T
+	for bucket = range s.buckets {
+		a = &s.buckets[bucket]
+		if x <= (*a)[len(*a)-1] {
+			break
+		}
+	}
+	i, _ := slices.BinarySearch(*a, x)
+	return a, bucket, i
+}
+
+func (s *SortedMultiset[T]) Contains(x T) bool {
+	if s.size == 0 {
+		return false
+	}
+	a, _, i := s.position(x)
+	return i < len(*a) && (*a)[i] == x
+}
+
+// Count returns the number of occurrences of x.
+func (s *SortedMultiset[T]) Count(x T) int {
+	return s.IndexRight(x) - s.Index(x)
+}
+
+func (s *SortedMultiset[T]) Add(x T) {
+	if s.size == 0 {
+		s.buckets = [][]T{{x}}
+		s.size = 1
+		return
+	}
+	a, b, i := s.position(x)
+	*a = slices.Insert(*a, i, x)
+	s.size++
+
+	s.buckets = splitBucket(s.buckets, b, &defaultOptions)
+}
+
+func (s *SortedMultiset[T]) pop(b int, i int) T {
+	a := &s.buckets[b]
+	ans := (*a)[i]
+	*a = slices.Delete(*a, i, i+1)
+	s.size--
+	if len(*a) == 0 {
+		s.buckets = slices.Delete(s.buckets, b, b+1)
+	}
+	return ans
+}
+
+// Discard removes one occurrence of x.
+func (s *SortedMultiset[T]) Discard(x T) bool {
+	if s.size == 0 {
+		return false
+	}
+	a, b, i := s.position(x)
+	if i == len(*a) || (*a)[i] != x {
+		return false
+	}
+	_ = s.pop(b, i)
+
+	return true
+}
+
+func (s *SortedMultiset[T]) Lt(x T) (T, bool) {
+	for i := range s.buckets {
+		a := s.buckets[len(s.buckets)-i-1]
+		if a[0] < x {
+			j, _ := slices.BinarySearch(a, x)
+			return a[j-1], true
+		}
+	}
+	var v T
+	return v, false
+}
+
+func (s *SortedMultiset[T]) Le(x T) (T, bool) {
+	for i := range s.buckets {
+		a := s.buckets[len(s.buckets)-i-1]
+		if a[0] <= x {
+			return a[bisectRight(a, x)-1], true
+		}
+	}
+	var v T
+	return v, false
+}
+
+func (s *SortedMultiset[T]) Gt(x T) (T, bool) {
+	for _, a := range s.buckets {
+		if a[len(a)-1] > x {
+			return a[bisectRight(a, x)], true
+		}
+	}
+	var v T
+	return v, false
+}
+
+func (s *SortedMultiset[T]) Ge(x T) (T, bool) {
+	for _, a := range s.buckets {
+		if a[len(a)-1] >= x {
+			j, _ := slices.BinarySearch(a, x)
+			return a[j], true
+		}
+	}
+	var v T
+	return v, false
+}
+
+func (s *SortedMultiset[T]) GetItem(idx int) (T, error) {
+	if idx < 0 {
+		for i := range s.buckets {
+			a := s.buckets[len(s.buckets)-i-1]
+			idx += len(a)
+			if idx >= 0 {
+				return a[idx], nil
+			}
+		}
+	} else {
+		for _, a := range s.buckets {
+			if idx < len(a) {
+				return a[idx], nil
+			}
+			idx -= len(a)
+		}
+	}
+
+	var v T
+	return v, ErrIndexOutOfRange
+}
+
+func (s *SortedMultiset[T]) Pop(idx int) (T, error) {
+	if idx < 0 {
+		for i := range s.buckets {
+			b := len(s.buckets) - i - 1
+			idx += len(s.buckets[b])
+			if idx >= 0 {
+				return s.pop(b, idx), nil
+			}
+		}
+	} else {
+		for b := range s.buckets {
+			if idx < len(s.buckets[b]) {
+				return s.pop(b, idx), nil
+			}
+			idx -= len(s.buckets[b])
+		}
+	}
+	var v T
+	return v, ErrIndexOutOfRange
+}
+
+// Index returns the number of elements less than x.
+func (s *SortedMultiset[T]) Index(x T) int {
+	ans := 0
+	for _, a := range s.buckets {
+		if a[len(a)-1] >= x {
+			i, _ := slices.BinarySearch(a, x)
+			return ans + i
+		}
+		ans += len(a)
+	}
+	return ans
+}
+
+// IndexRight returns the number of elements less than or equal to x.
+func (s *SortedMultiset[T]) IndexRight(x T) int {
+	ans := 0
+	for _, a := range s.buckets {
+		if a[len(a)-1] > x {
+			return ans + bisectRight(a, x)
+		}
+		ans += len(a)
+	}
+	return ans
+}
@@ -0,0 +1,401 @@
+package gosortedset_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestNewMultiset(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial         []int
+		expected        []int
+		expectedBuckets [][]int
+	}{
+		"ok": {
+			initial:         []int{1, 2, 3, 4, 5},
+			expected:        []int{1, 2, 3, 4, 5},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 5}},
+		},
+		"empty": {
+			initial:         []int{},
+			expected:        []int{},
+			expectedBuckets: [][]int{},
+		},
+		"duplicate": {
+			initial:         []int{5, 1, 5, 3, 1, 5},
+			expected:        []int{1, 1, 3, 5, 5, 5},
+			expectedBuckets: [][]int{{1, 1, 3, 5, 5, 5}},
+		},
+		"multiple buckets": {
+			initial:         []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+			expected:        []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+			expectedBuckets: [][]int{{1, 1, 1, 1, 1, 1, 1, 1}, {1, 2, 2, 2, 2, 2, 2, 2, 2}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := gosortedset.NewMultiset(testCase.initial)
+			assertEqualSlice(t, testCase.expected, slices.Collect(ms.Values()))
+			assertEqualBuckets(t, testCase.expectedBuckets, ms.Buckets())
+			if ms.Len() != len(testCase.expected) {
+				t.Errorf("expected %v, got %v", len(testCase.expected), ms.Len())
+			}
+		})
+	}
+}
+
+func TestMultisetAdd(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial         []int
+		operation       func(ms *gosortedset.SortedMultiset[int])
+		expected        []int
+		expectedBuckets [][]int
+	}{
+		"ok": {
+			initial: []int{1, 2, 4, 5},
+			operation: func(ms *gosortedset.SortedMultiset[int]) {
+				ms.Add(3)
+			},
+			expected:        []int{1, 2, 3, 4, 5},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 5}},
+		},
+		"add to empty": {
+			initial: []int{},
+			operation: func(ms *gosortedset.SortedMultiset[int]) {
+				ms.Add(1)
+			},
+			expected:        []int{1},
+			expectedBuckets: [][]int{{1}},
+		},
+		"contains same item": {
+			initial: []int{1, 2, 3, 4, 5},
+			operation: func(ms *gosortedset.SortedMultiset[int]) {
+				ms.Add(3)
+				ms.Add(3)
+			},
+			expected:        []int{1, 2, 3, 3, 3, 4, 5},
+			expectedBuckets: [][]int{{1, 2, 3, 3, 3, 4, 5}},
+		},
+		"add and split": {
+			initial: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			operation: func(ms *gosortedset.SortedMultiset[int]) {
+				for range 9 {
+					ms.Add(16)
+				}
+			},
+			expected:        []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, {13, 14, 15, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := gosortedset.NewMultiset(testCase.initial)
+			testCase.operation(ms)
+			assertEqualSlice(t, testCase.expected, slices.Collect(ms.Values()))
+			assertEqualBuckets(t, testCase.expectedBuckets, ms.Buckets())
+		})
+	}
+}
+
+func TestMultisetDiscard(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       []int
+		arg           int
+		expected      bool
+		result        []int
+		resultBuckets [][]int
+	}{
+		"ok": {
+			initial:       []int{1, 2, 3, 3, 4},
+			arg:           3,
+			expected:      true,
+			result:        []int{1, 2, 3, 4},
+			resultBuckets: [][]int{{1, 2, 3, 4}},
+		},
+		"not contains": {
+			initial:       []int{1, 2, 3, 4, 5},
+			arg:           6,
+			expected:      false,
+			result:        []int{1, 2, 3, 4, 5},
+			resultBuckets: [][]int{{1, 2, 3, 4, 5}},
+		},
+		"empty": {
+			initial:       []int{},
+			arg:           1,
+			expected:      false,
+			result:        []int{},
+			resultBuckets: [][]int{},
+		},
+		"set empty": {
+			initial:       []int{1},
+			arg:           1,
+			expected:      true,
+			result:        []int{},
+			resultBuckets: [][]int{},
+		},
+		"across buckets": {
+			initial:       []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+			arg:           2,
+			expected:      true,
+			result:        []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2},
+			resultBuckets: [][]int{{1, 1, 1, 1, 1, 1, 1, 1}, {1, 2, 2, 2, 2, 2, 2, 2}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := gosortedset.NewMultiset(testCase.initial)
+			if result := ms.Discard(testCase.arg); result != testCase.expected {
+				t.Errorf("expected %v, got %v", testCase.expected, result)
+			}
+
+			assertEqualSlice(t, testCase.result, slices.Collect(ms.Values()))
+			assertEqualBuckets(t, testCase.resultBuckets, ms.Buckets())
+		})
+	}
+}
+
+func TestMultisetCount(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       []int
+		arg           int
+		expected      int
+		expectedIndex int
+		expectedRight int
+	}{
+		"ok": {
+			initial:       []int{1, 2, 3, 3, 3, 4},
+			arg:           3,
+			expected:      3,
+			expectedIndex: 2,
+			expectedRight: 5,
+		},
+		"not contains": {
+			initial:       []int{1, 2, 4, 5},
+			arg:           3,
+			expected:      0,
+			expectedIndex: 2,
+			expectedRight: 2,
+		},
+		"empty": {
+			initial:       []int{},
+			arg:           1,
+			expected:      0,
+			expectedIndex: 0,
+			expectedRight: 0,
+		},
+		"across buckets": {
+			initial:       []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+			arg:           1,
+			expected:      9,
+			expectedIndex: 0,
+			expectedRight: 9,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := gosortedset.NewMultiset(testCase.initial)
+			if count := ms.Count(testCase.arg); count != testCase.expected {
+				t.Errorf("expected %v, got %v", testCase.expected, count)
+			}
+			if index := ms.Index(testCase.arg); index != testCase.expectedIndex {
+				t.Errorf("expected index %v, got %v", testCase.expectedIndex, index)
+			}
+			if index := ms.IndexRight(testCase.arg); index != testCase.expectedRight {
+				t.Errorf("expected index right %v, got %v", testCase.expectedRight, index)
+			}
+		})
+	}
+}
+
+func TestMultisetBounds(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		value int
+		exist bool
+	}
+
+	testCases := map[string]struct {
+		initial []int
+		arg     int
+		lt      result
+		le      result
+		gt      result
+		ge      result
+	}{
+		"contains": {
+			initial: []int{1, 3, 3, 3, 5},
+			arg:     3,
+			lt:      result{1, true},
+			le:      result{3, true},
+			gt:      result{5, true},
+			ge:      result{3, true},
+		},
+		"not contains": {
+			initial: []int{1, 1, 5, 5},
+			arg:     3,
+			lt:      result{1, true},
+			le:      result{1, true},
+			gt:      result{5, true},
+			ge:      result{5, true},
+		},
+		"smaller than all": {
+			initial: []int{1, 1, 5, 5},
+			arg:     1,
+			lt:      result{0, false},
+			le:      result{1, true},
+			gt:      result{5, true},
+			ge:      result{1, true},
+		},
+		"larger than all": {
+			initial: []int{1, 1, 5, 5},
+			arg:     5,
+			lt:      result{1, true},
+			le:      result{5, true},
+			gt:      result{0, false},
+			ge:      result{5, true},
+		},
+		"empty": {
+			initial: []int{},
+			arg:     1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := gosortedset.NewMultiset(testCase.initial)
+			check := func(op string, expected result, value int, ok bool) {
+				t.Helper()
+				if value != expected.value || ok != expected.exist {
+					t.Errorf("%s: expected %v, %v, got %v, %v", op, expected.value, expected.exist, value, ok)
+				}
+			}
+			value, ok := ms.Lt(testCase.arg)
+			check("Lt", testCase.lt, value, ok)
+			value, ok = ms.Le(testCase.arg)
+			check("Le", testCase.le, value, ok)
+			value, ok = ms.Gt(testCase.arg)
+			check("Gt", testCase.gt, value, ok)
+			value, ok = ms.Ge(testCase.arg)
+			check("Ge", testCase.ge, value, ok)
+		})
+	}
+}
+
+func TestMultisetPop(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       []int
+		arg           int
+		expectedValue int
+		expectedError error
+		expected      []int
+	}{
+		"ok": {
+			initial:       []int{1, 2, 2, 3},
+			arg:           1,
+			expectedValue: 2,
+			expected:      []int{1, 2, 3},
+		},
+		"negative index": {
+			initial:       []int{1, 2, 3, 3},
+			arg:           -1,
+			expectedValue: 3,
+			expected:      []int{1, 2, 3},
+		},
+		"index out of range": {
+			initial:       []int{1, 2, 3},
+			arg:           3,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+		"negative index out of range": {
+			initial:       []int{1, 2, 3},
+			arg:           -4,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+		"empty": {
+			initial:       []int{},
+			arg:           0,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := gosortedset.NewMultiset(testCase.initial)
+			item, err := ms.GetItem(testCase.arg)
+			value, popErr := ms.Pop(testCase.arg)
+			if testCase.expectedError != nil {
+				if !errors.Is(err, testCase.expectedError) || !errors.Is(popErr, testCase.expectedError) {
+					t.Errorf("expected error %v, got %v, %v", testCase.expectedError, err, popErr)
+				}
+				return
+			}
+
+			if item != testCase.expectedValue || value != testCase.expectedValue {
+				t.Errorf("expected %v, got %v, %v", testCase.expectedValue, item, value)
+			}
+			assertEqualSlice(t, testCase.expected, slices.Collect(ms.Values()))
+		})
+	}
+}
+
+func TestMultisetEquals(t *testing.T) {
+	t.Parallel()
+
+	many := []int{1, 1, 2, 3, 3, 3, 4, 5, 6, 7, 8, 8, 9, 10, 11, 12, 13}
+	testCases := map[string]struct {
+		a, b     []int
+		expected bool
+	}{
+		"equal":            {a: []int{1, 2, 2}, b: []int{2, 1, 2}, expected: true},
+		"different count":  {a: []int{1, 2, 2}, b: []int{1, 1, 2}, expected: false},
+		"different length": {a: []int{1, 2, 2}, b: []int{1, 2}, expected: false},
+		"both empty":       {a: []int{}, b: []int{}, expected: true},
+		"multiple buckets": {a: many, b: many, expected: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := gosortedset.NewMultiset(slices.Clone(testCase.a))
+			// build b one by one so that it is bucketed differently from a
+			b := gosortedset.NewMultiset([]int{})
+			for _, v := range testCase.b {
+				b.Add(v)
+			}
+			if a.Equals(b) != testCase.expected || b.Equals(a) != testCase.expected {
+				t.Errorf("expected %v, got %v, %v", testCase.expected, a.Equals(b), b.Equals(a))
+			}
+		})
+	}
+}
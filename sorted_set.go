@@ -4,8 +4,8 @@ import (
 	"cmp"
 	"fmt"
 	"iter"
-	"math"
 	"slices"
+	"sort"
 	"strings"
 )
 
@@ -17,84 +17,266 @@ const (
 type SortedSet[T cmp.Ordered] struct {
 	buckets [][]T
 	size    int
+
+	// shared[b] is true if buckets[b] may be shared with a snapshot,
+	// in which case it must be copied before being modified in place. nil if no bucket is shared.
+	shared []bool
+
+	// sizes is a Fenwick tree over the lengths of buckets, used to convert between indexes and positions.
+	sizes fenwick
+
+	// opts holds the bucket sizing given to NewWithOptions. nil means the default options.
+	opts *options
+
+	// mods counts the changes to the elements, so that cursors can detect that the set was modified.
+	mods uint64
 }
 
 func New[T cmp.Ordered](a []T) *SortedSet[T] {
-	s := &SortedSet[T]{}
+	return fromSorted(sortUnique(a), nil)
+}
+
+// sort a in place and remove duplicates.
+func sortUnique[T cmp.Ordered](a []T) []T {
 	if !slices.IsSorted(a) {
 		slices.Sort(a)
 	}
-	a = slices.Compact(a)
-	n := len(a)
+	return slices.Compact(a)
+}
 
-	s.size = n
+// build a set with the given options from a sorted slice without duplicates.
+func fromSorted[T cmp.Ordered](a []T, opts *options) *SortedSet[T] {
+	s := &SortedSet[T]{opts: opts}
+	s.reset(a)
 
-	numBucket := int(math.Ceil(math.Sqrt(float64(n) / float64(bucketRatio))))
+	return s
+}
 
-	s.buckets = make([][]T, numBucket)
-	for i := 0; i < numBucket; i++ {
-		s.buckets[i] = make([]T, 0, n/numBucket)
-		s.buckets[i] = append(s.buckets[i], a[i*n/numBucket:(i+1)*n/numBucket]...)
-	}
+// replace the elements of s with a sorted slice without duplicates.
+func (s *SortedSet[T]) reset(a []T) {
+	s.size = len(a)
+	s.buckets = bucketize(a, s.options())
+	s.shared = nil
+	s.recount()
+	s.mods++
+}
 
-	return s
+// rebuild sizes after buckets were inserted, deleted or replaced.
+func (s *SortedSet[T]) recount() {
+	s.sizes = newFenwick(s.buckets)
 }
 
+// panic if s was modified since mods was read, which means that the body of a loop over an iterator changed s.
+// Breaking out of the loop right after changing s is allowed.
+func (s *SortedSet[T]) checkMods(mods uint64) {
+	if s.mods != mods {
+		panic(fmt.Errorf("%w during iteration", ErrModified))
+	}
+}
+
+// All returns an iterator over the elements in ascending order with their indexes.
+// It panics if the loop body modifies s and then continues the loop.
 func (s *SortedSet[T]) All() iter.Seq2[int, T] {
 	return func(yield func(int, T) bool) {
+		mods := s.mods
 		idx := 0
 		for _, bucket := range s.buckets {
 			for _, v := range bucket {
 				if !yield(idx, v) {
 					return
 				}
+				s.checkMods(mods)
 				idx++
 			}
 		}
 	}
 }
 
+// Values returns an iterator over the elements in ascending order.
+// It panics if the loop body modifies s and then continues the loop.
 func (s *SortedSet[T]) Values() iter.Seq[T] {
 	return func(yield func(T) bool) {
+		mods := s.mods
 		for _, bucket := range s.buckets {
 			for _, v := range bucket {
 				if !yield(v) {
 					return
 				}
+				s.checkMods(mods)
 			}
 		}
 	}
 }
 
+// Backward returns an iterator over the elements in descending order with their indexes.
+// It panics if the loop body modifies s and then continues the loop.
 func (s *SortedSet[T]) Backward() iter.Seq2[int, T] {
-	bucketNum := len(s.buckets)
 	return func(yield func(int, T) bool) {
+		mods := s.mods
 		idx := s.size - 1
-		for i := range s.buckets {
-			for j := range len(s.buckets[bucketNum-i-1]) {
-				if !yield(idx, s.buckets[bucketNum-i-1][len(s.buckets[bucketNum-i-1])-j-1]) {
+		for _, bucket := range slices.Backward(s.buckets) {
+			for _, v := range slices.Backward(bucket) {
+				if !yield(idx, v) {
 					return
 				}
+				s.checkMods(mods)
 				idx--
 			}
 		}
 	}
 }
 
+// ValuesFrom returns an iterator over the elements which are >= x, or > x if inclusive is false,
+// in ascending order with their indexes in s. It panics in the same way as All if s is modified.
+func (s *SortedSet[T]) ValuesFrom(x T, inclusive bool) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		mods := s.mods
+		b, i := s.bisect(x, !inclusive)
+		idx := s.offset(b, i)
+		for ; b < len(s.buckets); b, i = b+1, 0 {
+			for _, v := range s.buckets[b][i:] {
+				if !yield(idx, v) {
+					return
+				}
+				s.checkMods(mods)
+				idx++
+			}
+		}
+	}
+}
+
+// BackwardFrom returns an iterator over the elements which are <= x, or < x if inclusive is false,
+// in descending order with their indexes in s. It panics in the same way as Backward if s is modified.
+func (s *SortedSet[T]) BackwardFrom(x T, inclusive bool) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		mods := s.mods
+		b, i := s.bisect(x, inclusive)
+		idx := s.offset(b, i) - 1
+		for b >= 0 {
+			for j := i - 1; j >= 0; j-- {
+				if !yield(idx, s.buckets[b][j]) {
+					return
+				}
+				s.checkMods(mods)
+				idx--
+			}
+			b--
+			if b >= 0 {
+				i = len(s.buckets[b])
+			}
+		}
+	}
+}
+
+// Slice returns an iterator over s[i:j] with the indexes of the elements, like Python's slicing.
+// Negative indices count from the end, and out of range indices are clamped.
+func (s *SortedSet[T]) Slice(i, j int) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		mods := s.mods
+		i, j := s.clamp(i), s.clamp(j)
+		b, k := s.locate(i)
+		for idx := i; idx < j; b, k = b+1, 0 {
+			for _, v := range s.buckets[b][k:min(len(s.buckets[b]), k+j-idx)] {
+				if !yield(idx, v) {
+					return
+				}
+				s.checkMods(mods)
+				idx++
+			}
+		}
+	}
+}
+
+// normalize a slice index in the same way as Python.
+func (s *SortedSet[T]) clamp(idx int) int {
+	if idx < 0 {
+		idx = max(idx+s.size, 0)
+	}
+	return min(idx, s.size)
+}
+
+// Range returns an iterator over the elements in [lo, hi) in ascending order.
+func (s *SortedSet[T]) Range(lo, hi T) iter.Seq[T] {
+	return s.rangeSeq(lo, hi, false)
+}
+
+// RangeInclusive returns an iterator over the elements in [lo, hi] in ascending order.
+func (s *SortedSet[T]) RangeInclusive(lo, hi T) iter.Seq[T] {
+	return s.rangeSeq(lo, hi, true)
+}
+
+// RangeBackward returns an iterator over the elements in [lo, hi) in descending order.
+func (s *SortedSet[T]) RangeBackward(lo, hi T) iter.Seq[T] {
+	return s.rangeBackwardSeq(lo, hi, false)
+}
+
+// RangeBackwardInclusive returns an iterator over the elements in [lo, hi] in descending order.
+func (s *SortedSet[T]) RangeBackwardInclusive(lo, hi T) iter.Seq[T] {
+	return s.rangeBackwardSeq(lo, hi, true)
+}
+
+func (s *SortedSet[T]) rangeSeq(lo, hi T, closed bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		mods := s.mods
+		b, i := s.bisect(lo, false)
+		for ; b < len(s.buckets); b, i = b+1, 0 {
+			for _, v := range s.buckets[b][i:] {
+				if v > hi || !closed && v == hi {
+					return
+				}
+				if !yield(v) {
+					return
+				}
+				s.checkMods(mods)
+			}
+		}
+	}
+}
+
+func (s *SortedSet[T]) rangeBackwardSeq(lo, hi T, closed bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		mods := s.mods
+		b, i := s.bisect(hi, closed)
+		for b >= 0 {
+			for j := i - 1; j >= 0; j-- {
+				v := s.buckets[b][j]
+				if v < lo {
+					return
+				}
+				if !yield(v) {
+					return
+				}
+				s.checkMods(mods)
+			}
+			b--
+			if b >= 0 {
+				i = len(s.buckets[b])
+			}
+		}
+	}
+}
+
 func (s *SortedSet[T]) Len() int {
 	return s.size
 }
 
+// Equals reports whether s and other have the same elements, regardless of how they are bucketed.
 func (s *SortedSet[T]) Equals(other *SortedSet[T]) bool {
-	if s.size != other.size {
-		return false
-	}
-	for i := range s.buckets {
-		if !slices.Equal(s.buckets[i], other.buckets[i]) {
-			return false
+	return s.size == other.size && s.Compare(other) == 0
+}
+
+// Compare compares the elements of s and other lexicographically.
+// The result is 0 if s equals other, -1 if s < other and +1 if s > other.
+func (s *SortedSet[T]) Compare(other *SortedSet[T]) int {
+	wa, wb := &walker[T]{buckets: s.buckets}, &walker[T]{buckets: other.buckets}
+	for !wa.done() && !wb.done() {
+		if c := cmp.Compare(wa.value(), wb.value()); c != 0 {
+			return c
 		}
+		wa.next()
+		wb.next()
 	}
-	return true
+	return cmp.Compare(s.size, other.size)
 }
 
 func (s *SortedSet[T]) String() string {
@@ -112,20 +294,68 @@ func (s *SortedSet[T]) String() string {
 	return sb.String()
 }
 
+// return the index of the first bucket whose last element is >= x, or > x if right is true.
+// The last elements of the buckets are sorted, so this is a binary search over the buckets.
+func (s *SortedSet[T]) findBucket(x T, right bool) int {
+	return sort.Search(len(s.buckets), func(b int) bool {
+		last := s.buckets[b][len(s.buckets[b])-1]
+		return last > x || !right && last == x
+	})
+}
+
 // return the bucket, index of the bucket and position in which x should be.
 func (s *SortedSet[T]) position(x T) (*[]T, int, int) {
-	var bucket int
-	var a *[]T
-	for bucket = range s.buckets {
-		a = &s.buckets[bucket]
-		if x <= (*a)[len(*a)-1] {
-			break
-		}
-	}
+	bucket := min(s.findBucket(x, false), len(s.buckets)-1)
+	a := &s.buckets[bucket]
 	i, _ := slices.BinarySearch(*a, x)
 	return a, bucket, i
 }
 
+// return the index of the bucket and position of the first element which is >= x, or > x if right is true.
+// If there is no such element, it returns (len(s.buckets), 0).
+func (s *SortedSet[T]) bisect(x T, right bool) (int, int) {
+	b := s.findBucket(x, right)
+	if b == len(s.buckets) {
+		return b, 0
+	}
+	if right {
+		return b, bisectRight(s.buckets[b], x)
+	}
+	i, _ := slices.BinarySearch(s.buckets[b], x)
+	return b, i
+}
+
+// return the element at position (b, i), or false if it is past the end.
+func (s *SortedSet[T]) at(b, i int) (T, bool) {
+	if b == len(s.buckets) {
+		var v T
+		return v, false
+	}
+	return s.buckets[b][i], true
+}
+
+// return the element just before position (b, i), or false if it is the first position.
+func (s *SortedSet[T]) before(b, i int) (T, bool) {
+	if i > 0 {
+		return s.buckets[b][i-1], true
+	}
+	if b == 0 {
+		var v T
+		return v, false
+	}
+	a := s.buckets[b-1]
+	return a[len(a)-1], true
+}
+
+// return the index of the bucket and position of the idx-th element (0 <= idx <= s.size).
+// If idx == s.size, it returns (len(s.buckets), 0).
+func (s *SortedSet[T]) locate(idx int) (int, int) {
+	if idx == s.size {
+		return len(s.buckets), 0
+	}
+	return s.sizes.search(idx)
+}
+
 func (s *SortedSet[T]) Contains(x T) bool {
 	if s.size == 0 {
 		return false
@@ -136,40 +366,123 @@ func (s *SortedSet[T]) Contains(x T) bool {
 
 func (s *SortedSet[T]) Add(x T) bool {
 	if s.size == 0 {
-		s.buckets = [][]T{{x}}
-		s.size = 1
+		s.reset([]T{x})
 		return true
 	}
 	a, b, i := s.position(x)
 	if i != len(*a) && (*a)[i] == x {
 		return false
 	}
+	s.own(b)
 	*a = slices.Insert(*a, i, x)
 	s.buckets[b] = *a
 	s.size++
+	s.mods++
 
-	if len(*a) > len(s.buckets)*splitRatio {
-		mid := len(*a) >> 1
-		s.buckets = slices.Insert(s.buckets, b+1, (*a)[mid:])
-		s.buckets[b] = (*a)[:mid]
+	n := len(s.buckets)
+	s.buckets = splitBucket(s.buckets, b, s.options())
+	if len(s.buckets) == n {
+		s.sizes.add(b, 1)
+		return true
+	}
+	if s.shared != nil {
+		s.shared = slices.Insert(s.shared, b+1, false)
 	}
+	s.recount()
 	return true
 }
 
-func (s *SortedSet[T]) pop(a *[]T, b int, i int) T {
-	ans := (*a)[i]
-	*a = slices.Delete(*a, i, i+1)[:len(*a)-1]
+// AddAll adds all the elements of seq and returns how many of them were newly added.
+func (s *SortedSet[T]) AddAll(seq iter.Seq[T]) int {
+	return s.addSlice(slices.Collect(seq))
+}
+
+// AddSlice adds all the elements of a and returns how many of them were newly added.
+// The batch is merged with s in one pass and s is rebucketed in the same way as New.
+// Unlike New, it leaves a untouched.
+func (s *SortedSet[T]) AddSlice(a []T) int {
+	return s.addSlice(slices.Clone(a))
+}
+
+// add the elements of a, which s may sort and modify.
+func (s *SortedSet[T]) addSlice(a []T) int {
+	if len(a) == 0 {
+		return 0
+	}
+	a = sortUnique(a)
+
+	n := s.size
+	batch := &SortedSet[T]{buckets: [][]T{a}, size: len(a)}
+	s.reset(mergeSlice(s, batch, s.size+batch.size, keepUnion))
+
+	return s.size - n
+}
+
+func (s *SortedSet[T]) pop(b int, i int) T {
+	s.own(b)
+	ans := s.buckets[b][i]
+	s.buckets[b] = slices.Delete(s.buckets[b], i, i+1)
 	s.size--
-	if len(*a) == 0 {
-		if b < 0 {
-			b = b + len(s.buckets)
-		}
+	s.mods++
+	s.shrink(b, 1)
+	return ans
+}
+
+// fix up buckets[b] after d of its elements were removed.
+// An emptied bucket is removed, and a bucket which became too small is merged into a neighbour.
+func (s *SortedSet[T]) shrink(b, d int) {
+	switch {
+	case len(s.buckets[b]) == 0:
 		s.buckets = slices.Delete(s.buckets, b, b+1)
-		if len(s.buckets) > 1 { // TODO: 上で代入してるから、ここは必要ではないが、どっちが速いか調べる必要がある
-			s.buckets = s.buckets[:len(s.buckets)-1]
+		s.deleteShared(b, b+1)
+		s.splitOversized()
+		s.recount()
+	case s.underflow(b):
+		s.mergeBucket(b)
+		s.splitOversized()
+		s.recount()
+	default:
+		s.sizes.add(b, -d)
+	}
+}
+
+// report whether buckets[b] is below the merge threshold and has a neighbour to be merged into.
+func (s *SortedSet[T]) underflow(b int) bool {
+	return len(s.buckets) > 1 && len(s.buckets[b]) < s.options().mergeBound(len(s.buckets))
+}
+
+// merge buckets[b] into the smaller of its neighbours.
+// The caller restores the split bound with splitOversized and rebuilds the rank index afterwards.
+func (s *SortedSet[T]) mergeBucket(b int) {
+	if b == len(s.buckets)-1 || b > 0 && len(s.buckets[b-1]) < len(s.buckets[b+1]) {
+		b--
+	}
+	n := len(s.buckets)
+	// joinBuckets copies both buckets, so the result is never shared
+	s.buckets = joinBuckets(s.buckets, b, s.options())
+	if s.shared != nil {
+		s.shared[b] = false
+		if len(s.buckets) < n {
+			s.deleteShared(b+1, b+2)
+		} else {
+			s.shared[b+1] = false
+		}
+	}
+}
+
+// split the buckets which exceed the split bound.
+// The bound shrinks with the number of buckets, so this is needed whenever buckets are removed.
+func (s *SortedSet[T]) splitOversized() {
+	o := s.options()
+	for b := 0; b < len(s.buckets); b++ {
+		for len(s.buckets[b]) > len(s.buckets)*o.splitRatio {
+			s.buckets = splitBucket(s.buckets, b, o)
+			// both halves still point to the original array
+			if s.shared != nil {
+				s.shared = slices.Insert(s.shared, b+1, s.shared[b])
+			}
 		}
 	}
-	return ans
 }
 
 func (s *SortedSet[T]) Discard(x T) bool {
@@ -180,134 +493,201 @@ func (s *SortedSet[T]) Discard(x T) bool {
 	if i == len(*a) || (*a)[i] != x {
 		return false
 	}
-	_ = s.pop(a, b, i)
+	_ = s.pop(b, i)
 
 	return true
 }
 
 func (s *SortedSet[T]) Lt(x T) (T, bool) {
-	for i := range s.buckets {
-		a := s.buckets[len(s.buckets)-i-1]
-		if a[0] < x {
-			j, _ := slices.BinarySearch(a, x)
-			return a[j-1], true
-		}
-	}
-	var v T
-	return v, false
+	return s.before(s.bisect(x, false))
 }
 
 func (s *SortedSet[T]) Le(x T) (T, bool) {
-	for i := range s.buckets {
-		a := s.buckets[len(s.buckets)-i-1]
-		if a[0] <= x {
-			j, ok := slices.BinarySearch(a, x)
-			if !ok {
-				return a[j-1], true
-			}
-			return a[j], true
-		}
-	}
-	var v T
-	return v, false
+	return s.before(s.bisect(x, true))
 }
 
 func (s *SortedSet[T]) Gt(x T) (T, bool) {
-	for _, a := range s.buckets {
-		if a[len(a)-1] > x {
-			j, ok := slices.BinarySearch(a, x)
-			if !ok {
-				return a[j], true
-			}
-			return a[j+1], true
-		}
-	}
-	var v T
-	return v, false
+	return s.at(s.bisect(x, true))
 }
 
 func (s *SortedSet[T]) Ge(x T) (T, bool) {
-	for _, a := range s.buckets {
-		if a[len(a)-1] >= x {
-			j, ok := slices.BinarySearch(a, x)
-			if !ok {
-				return a[j], true
-			}
-			return a[j], true
-		}
-	}
-	var v T
-	return v, false
+	return s.at(s.bisect(x, false))
 }
 
 func (s *SortedSet[T]) GetItem(idx int) (T, error) {
 	if idx < 0 {
-		for i := range s.buckets {
-			a := s.buckets[len(s.buckets)-i-1]
-			idx += len(a)
-			if idx >= 0 {
-				return a[idx], nil
-			}
-		}
-	} else {
-		for _, a := range s.buckets {
-			if idx < len(a) {
-				return a[idx], nil
-			}
-			idx -= len(a)
-		}
+		idx += s.size
 	}
-
-	var v T
-	return v, ErrIndexOutOfRange
+	if idx < 0 || idx >= s.size {
+		var v T
+		return v, ErrIndexOutOfRange
+	}
+	b, i := s.locate(idx)
+	return s.buckets[b][i], nil
 }
 
 func (s *SortedSet[T]) Pop(idx int) (T, error) {
 	if idx < 0 {
-		for b := range s.buckets {
-			a := &s.buckets[len(s.buckets)-b-1]
-			idx += len(*a)
-			if idx >= 0 {
-				v := s.pop(a, -(b + 1), idx)
-				return v, nil
-			}
-		}
-	} else {
-		for b := range s.buckets {
-			a := &s.buckets[b]
-			if idx < len(*a) {
-				return s.pop(a, b, idx), nil
-			}
-			idx -= len(*a)
-		}
+		idx += s.size
+	}
+	if idx < 0 || idx >= s.size {
+		var v T
+		return v, ErrIndexOutOfRange
 	}
-	var v T
-	return v, ErrIndexOutOfRange
+	b, i := s.locate(idx)
+	return s.pop(b, i), nil
 }
 
-func (s *SortedSet[T]) Index(x T) int {
-	ans := 0
-	for _, a := range s.buckets {
-		if a[len(a)-1] >= x {
-			i, _ := slices.BinarySearch(a, x)
-			return ans + i
-		}
-		ans += len(a)
+// Min returns the smallest element, or ErrEmpty if s is empty.
+func (s *SortedSet[T]) Min() (T, error) {
+	if s.size == 0 {
+		var v T
+		return v, ErrEmpty
+	}
+	return s.buckets[0][0], nil
+}
+
+// Max returns the largest element, or ErrEmpty if s is empty.
+func (s *SortedSet[T]) Max() (T, error) {
+	if s.size == 0 {
+		var v T
+		return v, ErrEmpty
+	}
+	a := s.buckets[len(s.buckets)-1]
+	return a[len(a)-1], nil
+}
+
+// PopMin removes and returns the smallest element, or returns ErrEmpty if s is empty.
+func (s *SortedSet[T]) PopMin() (T, error) {
+	if s.size == 0 {
+		var v T
+		return v, ErrEmpty
+	}
+	return s.pop(0, 0), nil
+}
+
+// PopMax removes and returns the largest element, or returns ErrEmpty if s is empty.
+func (s *SortedSet[T]) PopMax() (T, error) {
+	if s.size == 0 {
+		var v T
+		return v, ErrEmpty
 	}
+	b := len(s.buckets) - 1
+	return s.pop(b, len(s.buckets[b])-1), nil
+}
+
+// PopMinN removes the k smallest elements and returns them in ascending order.
+// If s has fewer than k elements, all of them are removed.
+func (s *SortedSet[T]) PopMinN(k int) []T {
+	ans, _ := s.PopRange(0, min(max(k, 0), s.size))
 	return ans
 }
 
-func (s *SortedSet[T]) IndexRight(x T) int {
-	ans := 0
-	for _, a := range s.buckets {
-		if a[len(a)-1] >= x {
-			i, ok := slices.BinarySearch(a, x)
-			if !ok {
-				return ans + i
-			}
-			return ans + i + 1
+// PopMaxN removes the k largest elements and returns them in descending order.
+// If s has fewer than k elements, all of them are removed.
+func (s *SortedSet[T]) PopMaxN(k int) []T {
+	ans, _ := s.PopRange(s.size-min(max(k, 0), s.size), s.size)
+	slices.Reverse(ans)
+	return ans
+}
+
+// DeleteRange removes the elements in [lo, hi) and returns how many were removed.
+func (s *SortedSet[T]) DeleteRange(lo, hi T) int {
+	if s.size == 0 || lo >= hi {
+		return 0
+	}
+	b1, i1 := s.bisect(lo, false)
+	b2, i2 := s.bisect(hi, false)
+	return s.deleteSpan(b1, i1, b2, i2)
+}
+
+// PopRange removes the elements s[i:j] and returns them in ascending order.
+// Negative indices count from the end as in Pop.
+func (s *SortedSet[T]) PopRange(i, j int) ([]T, error) {
+	if i < 0 {
+		i += s.size
+	}
+	if j < 0 {
+		j += s.size
+	}
+	if i < 0 || j > s.size || i > j {
+		return nil, ErrIndexOutOfRange
+	}
+
+	b1, i1 := s.locate(i)
+	b2, i2 := s.locate(j)
+	ans := make([]T, 0, j-i)
+	for b, k := b1, i1; b < b2 || b == b2 && k < i2; b, k = b+1, 0 {
+		end := len(s.buckets[b])
+		if b == b2 {
+			end = i2
 		}
-		ans += len(a)
+		ans = append(ans, s.buckets[b][k:end]...)
 	}
-	return ans
+	_ = s.deleteSpan(b1, i1, b2, i2)
+
+	return ans, nil
+}
+
+// remove the elements from position (b1, i1) up to position (b2, i2) and return how many were removed.
+// Whole buckets between them are dropped at once and only the edge buckets are trimmed.
+func (s *SortedSet[T]) deleteSpan(b1, i1, b2, i2 int) int {
+	if b1 == b2 {
+		if b1 == len(s.buckets) || i1 == i2 {
+			return 0
+		}
+		s.own(b1)
+		s.buckets[b1] = slices.Delete(s.buckets[b1], i1, i2)
+		s.size -= i2 - i1
+		s.mods++
+		s.shrink(b1, i2-i1)
+		return i2 - i1
+	}
+
+	n := len(s.buckets[b1]) - i1 + i2
+	for _, a := range s.buckets[b1+1 : b2] {
+		n += len(a)
+	}
+
+	lo, hi := b1+1, b2
+	s.buckets[b1] = s.buckets[b1][:i1]
+	if i1 == 0 {
+		lo = b1
+	}
+	if b2 < len(s.buckets) {
+		s.buckets[b2] = s.buckets[b2][i2:]
+		if len(s.buckets[b2]) == 0 {
+			hi = b2 + 1
+		}
+	}
+	s.buckets = slices.Delete(s.buckets, lo, hi)
+	s.deleteShared(lo, hi)
+
+	// the trimmed edge buckets are now buckets[lo] and buckets[b1]. Merge the right one first so that b1 stays valid,
+	// and split only afterwards since splitting shifts the indexes.
+	if hi == b2 && lo < len(s.buckets) && s.underflow(lo) {
+		s.mergeBucket(lo)
+	}
+	if i1 > 0 && s.underflow(b1) {
+		s.mergeBucket(b1)
+	}
+	s.splitOversized()
+	s.recount()
+	s.size -= n
+	s.mods++
+
+	return n
+}
+
+func (s *SortedSet[T]) Index(x T) int {
+	return s.offset(s.bisect(x, false))
+}
+
+func (s *SortedSet[T]) IndexRight(x T) int {
+	return s.offset(s.bisect(x, true))
+}
+
+// return the index in s of position (b, i).
+func (s *SortedSet[T]) offset(b, i int) int {
+	return s.sizes.prefix(b) + i
 }
@@ -0,0 +1,287 @@
+package gosortedset
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// SortedSetFunc is a SortedSet whose elements are ordered by a user comparator instead of <.
+// cmp must return a negative number when a < b, a positive number when a > b and zero when a == b.
+type SortedSetFunc[T any] struct {
+	buckets [][]T
+	size    int
+	cmp     func(a, b T) int
+}
+
+func NewFunc[T any](a []T, cmp func(a, b T) int) *SortedSetFunc[T] {
+	s := &SortedSetFunc[T]{cmp: cmp}
+	if !slices.IsSortedFunc(a, cmp) {
+		slices.SortFunc(a, cmp)
+	}
+	a = slices.CompactFunc(a, func(x, y T) bool { return cmp(x, y) == 0 })
+
+	s.size = len(a)
+	s.buckets = bucketize(a, &defaultOptions)
+
+	return s
+}
+
+func (s *SortedSetFunc[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		idx := 0
+		for _, bucket := range s.buckets {
+			for _, v := range bucket {
+				if !yield(idx, v) {
+					return
+				}
+				idx++
+			}
+		}
+	}
+}
+
+func (s *SortedSetFunc[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, bucket := range s.buckets {
+			for _, v := range bucket {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *SortedSetFunc[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		idx := s.size - 1
+		for i := len(s.buckets) - 1; i >= 0; i-- {
+			a := s.buckets[i]
+			for j := len(a) - 1; j >= 0; j-- {
+				if !yield(idx, a[j]) {
+					return
+				}
+				idx--
+			}
+		}
+	}
+}
+
+func (s *SortedSetFunc[T]) Len() int {
+	return s.size
+}
+
+func (s *SortedSetFunc[T]) Equals(other *SortedSetFunc[T]) bool {
+	if s.size != other.size {
+		return false
+	}
+	wa, wb := &walker[T]{buckets: s.buckets}, &walker[T]{buckets: other.buckets}
+	for ; !wa.done(); wa.next() {
+		if s.cmp(wa.value(), wb.value()) != 0 {
+			return false
+		}
+		wb.next()
+	}
+	return true
+}
+
+func (s *SortedSetFunc[T]) String() string {
+	sb := &strings.Builder{}
+	_, _ = sb.WriteString("SortedSetFunc{")
+
+	for i := range s.buckets {
+		for j := range s.buckets[i] {
+			_, _ = sb.WriteString(fmt.Sprintf("%v, ", s.buckets[i][j]))
+		}
+	}
+
+	_, _ = sb.WriteString("}")
+
+	return sb.String()
+}
+
+// return the bucket, index of the bucket and position in which x should be, and whether x is there.
+func (s *SortedSetFunc[T]) position(x T) (*[]T, int, int, bool) {
+	var bucket int
+	var a *[]T
+	for bucket = range s.buckets {
+		a = &s.buckets[bucket]
+		if s.cmp(x, (*a)[len(*a)-1]) <= 0 {
+			break
+		}
+	}
+	i, ok := slices.BinarySearchFunc(*a, x, s.cmp)
+	return a, bucket, i, ok
+}
+
+func (s *SortedSetFunc[T]) Contains(x T) bool {
+	if s.size == 0 {
+		return false
+	}
+	_, _, _, ok := s.position(x)
+	return ok
+}
+
+func (s *SortedSetFunc[T]) Add(x T) bool {
+	if s.size == 0 {
+		s.buckets = [][]T{{x}}
+		s.size = 1
+		return true
+	}
+	a, b, i, ok := s.position(x)
+	if ok {
+		return false
+	}
+	*a = slices.Insert(*a, i, x)
+	s.size++
+
+	s.buckets = splitBucket(s.buckets, b, &defaultOptions)
+	return true
+}
+
+func (s *SortedSetFunc[T]) pop(b int, i int) T {
+	a := &s.buckets[b]
+	ans := (*a)[i]
+	*a = slices.Delete(*a, i, i+1)
+	s.size--
+	if len(*a) == 0 {
+		s.buckets = slices.Delete(s.buckets, b, b+1)
+	}
+	return ans
+}
+
+func (s *SortedSetFunc[T]) Discard(x T) bool {
+	if s.size == 0 {
+		return false
+	}
+	_, b, i, ok := s.position(x)
+	if !ok {
+		return false
+	}
+	_ = s.pop(b, i)
+
+	return true
+}
+
+func (s *SortedSetFunc[T]) Lt(x T) (T, bool) {
+	for i := len(s.buckets) - 1; i >= 0; i-- {
+		a := s.buckets[i]
+		if s.cmp(a[0], x) < 0 {
+			j, _ := slices.BinarySearchFunc(a, x, s.cmp)
+			return a[j-1], true
+		}
+	}
+	var v T
+	return v, false
+}
+
+func (s *SortedSetFunc[T]) Le(x T) (T, bool) {
+	for i := len(s.buckets) - 1; i >= 0; i-- {
+		a := s.buckets[i]
+		if s.cmp(a[0], x) <= 0 {
+			j, ok := slices.BinarySearchFunc(a, x, s.cmp)
+			if !ok {
+				return a[j-1], true
+			}
+			return a[j], true
+		}
+	}
+	var v T
+	return v, false
+}
+
+func (s *SortedSetFunc[T]) Gt(x T) (T, bool) {
+	for _, a := range s.buckets {
+		if s.cmp(a[len(a)-1], x) > 0 {
+			j, ok := slices.BinarySearchFunc(a, x, s.cmp)
+			if !ok {
+				return a[j], true
+			}
+			return a[j+1], true
+		}
+	}
+	var v T
+	return v, false
+}
+
+func (s *SortedSetFunc[T]) Ge(x T) (T, bool) {
+	for _, a := range s.buckets {
+		if s.cmp(a[len(a)-1], x) >= 0 {
+			j, _ := slices.BinarySearchFunc(a, x, s.cmp)
+			return a[j], true
+		}
+	}
+	var v T
+	return v, false
+}
+
+func (s *SortedSetFunc[T]) GetItem(idx int) (T, error) {
+	if idx < 0 {
+		for i := len(s.buckets) - 1; i >= 0; i-- {
+			a := s.buckets[i]
+			idx += len(a)
+			if idx >= 0 {
+				return a[idx], nil
+			}
+		}
+	} else {
+		for _, a := range s.buckets {
+			if idx < len(a) {
+				return a[idx], nil
+			}
+			idx -= len(a)
+		}
+	}
+
+	var v T
+	return v, ErrIndexOutOfRange
+}
+
+func (s *SortedSetFunc[T]) Pop(idx int) (T, error) {
+	if idx < 0 {
+		for b := len(s.buckets) - 1; b >= 0; b-- {
+			idx += len(s.buckets[b])
+			if idx >= 0 {
+				return s.pop(b, idx), nil
+			}
+		}
+	} else {
+		for b := range s.buckets {
+			if idx < len(s.buckets[b]) {
+				return s.pop(b, idx), nil
+			}
+			idx -= len(s.buckets[b])
+		}
+	}
+	var v T
+	return v, ErrIndexOutOfRange
+}
+
+func (s *SortedSetFunc[T]) Index(x T) int {
+	ans := 0
+	for _, a := range s.buckets {
+		if s.cmp(a[len(a)-1], x) >= 0 {
+			i, _ := slices.BinarySearchFunc(a, x, s.cmp)
+			return ans + i
+		}
+		ans += len(a)
+	}
+	return ans
+}
+
+func (s *SortedSetFunc[T]) IndexRight(x T) int {
+	ans := 0
+	for _, a := range s.buckets {
+		if s.cmp(a[len(a)-1], x) >= 0 {
+			i, ok := slices.BinarySearchFunc(a, x, s.cmp)
+			if !ok {
+				return ans + i
+			}
+			return ans + i + 1
+		}
+		ans += len(a)
+	}
+	return ans
+}
@@ -0,0 +1,364 @@
+package gosortedset_test
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+type pair struct {
+	first  int
+	second string
+}
+
+func comparePair(a, b pair) int {
+	if c := cmp.Compare(a.first, b.first); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.second, b.second)
+}
+
+func TestNewFunc(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial         []pair
+		expected        []pair
+		expectedBuckets [][]pair
+	}{
+		"ok": {
+			initial:         []pair{{1, "a"}, {1, "b"}, {2, "a"}},
+			expected:        []pair{{1, "a"}, {1, "b"}, {2, "a"}},
+			expectedBuckets: [][]pair{{{1, "a"}, {1, "b"}, {2, "a"}}},
+		},
+		"empty": {
+			initial:         []pair{},
+			expected:        []pair{},
+			expectedBuckets: [][]pair{},
+		},
+		"not sorted": {
+			initial:         []pair{{2, "a"}, {1, "b"}, {1, "a"}},
+			expected:        []pair{{1, "a"}, {1, "b"}, {2, "a"}},
+			expectedBuckets: [][]pair{{{1, "a"}, {1, "b"}, {2, "a"}}},
+		},
+		"duplicate": {
+			initial:         []pair{{1, "a"}, {2, "a"}, {1, "a"}},
+			expected:        []pair{{1, "a"}, {2, "a"}},
+			expectedBuckets: [][]pair{{{1, "a"}, {2, "a"}}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.NewFunc(testCase.initial, comparePair)
+			assertEqualSlice(t, testCase.expected, slices.Collect(ss.Values()))
+			assertEqualBuckets(t, testCase.expectedBuckets, ss.Buckets())
+		})
+	}
+}
+
+func TestFuncAddDiscard(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours ...int) []time.Time {
+		a := make([]time.Time, 0, len(hours))
+		for _, h := range hours {
+			a = append(a, base.Add(time.Duration(h)*time.Hour))
+		}
+		return a
+	}
+
+	testCases := map[string]struct {
+		initial   []time.Time
+		operation func(ss *gosortedset.SortedSetFunc[time.Time]) bool
+		expectedR bool
+		expected  []time.Time
+	}{
+		"add": {
+			initial: at(1, 3),
+			operation: func(ss *gosortedset.SortedSetFunc[time.Time]) bool {
+				return ss.Add(base.Add(2 * time.Hour))
+			},
+			expectedR: true,
+			expected:  at(1, 2, 3),
+		},
+		"add same instant in another location": {
+			initial: at(1, 3),
+			operation: func(ss *gosortedset.SortedSetFunc[time.Time]) bool {
+				return ss.Add(base.Add(time.Hour).In(time.FixedZone("JST", 9*60*60)))
+			},
+			expectedR: false,
+			expected:  at(1, 3),
+		},
+		"add to empty": {
+			initial: at(),
+			operation: func(ss *gosortedset.SortedSetFunc[time.Time]) bool {
+				return ss.Add(base)
+			},
+			expectedR: true,
+			expected:  at(0),
+		},
+		"discard": {
+			initial: at(1, 2, 3),
+			operation: func(ss *gosortedset.SortedSetFunc[time.Time]) bool {
+				return ss.Discard(base.Add(2 * time.Hour))
+			},
+			expectedR: true,
+			expected:  at(1, 3),
+		},
+		"discard not contains": {
+			initial: at(1, 3),
+			operation: func(ss *gosortedset.SortedSetFunc[time.Time]) bool {
+				return ss.Discard(base.Add(2 * time.Hour))
+			},
+			expectedR: false,
+			expected:  at(1, 3),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.NewFunc(testCase.initial, time.Time.Compare)
+			if result := testCase.operation(ss); result != testCase.expectedR {
+				t.Errorf("expected %v, got %v", testCase.expectedR, result)
+			}
+			if !slices.EqualFunc(testCase.expected, slices.Collect(ss.Values()), time.Time.Equal) {
+				t.Errorf("expected %v, got %v", testCase.expected, slices.Collect(ss.Values()))
+			}
+			for _, v := range testCase.expected {
+				if !ss.Contains(v) {
+					t.Errorf("expected %v to be contained", v)
+				}
+			}
+		})
+	}
+}
+
+func TestFuncAddAndSplit(t *testing.T) {
+	t.Parallel()
+
+	ss := gosortedset.NewFunc([]pair{}, comparePair)
+	for i := 25; i >= 1; i-- {
+		ss.Add(pair{i, ""})
+	}
+
+	expected := make([]pair, 0, 25)
+	for i := 1; i <= 25; i++ {
+		expected = append(expected, pair{i, ""})
+	}
+	assertEqualSlice(t, expected, slices.Collect(ss.Values()))
+	assertEqualBuckets(t, [][]pair{expected[:12], expected[12:]}, ss.Buckets())
+}
+
+func TestFuncBounds(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		value pair
+		exist bool
+	}
+
+	testCases := map[string]struct {
+		initial []pair
+		arg     pair
+		lt      result
+		le      result
+		gt      result
+		ge      result
+	}{
+		"contains": {
+			initial: []pair{{1, "a"}, {2, "a"}, {2, "b"}},
+			arg:     pair{2, "a"},
+			lt:      result{pair{1, "a"}, true},
+			le:      result{pair{2, "a"}, true},
+			gt:      result{pair{2, "b"}, true},
+			ge:      result{pair{2, "a"}, true},
+		},
+		"not contains": {
+			initial: []pair{{1, "a"}, {2, "b"}},
+			arg:     pair{2, "a"},
+			lt:      result{pair{1, "a"}, true},
+			le:      result{pair{1, "a"}, true},
+			gt:      result{pair{2, "b"}, true},
+			ge:      result{pair{2, "b"}, true},
+		},
+		"out of range": {
+			initial: []pair{{1, "a"}},
+			arg:     pair{1, "a"},
+			le:      result{pair{1, "a"}, true},
+			ge:      result{pair{1, "a"}, true},
+		},
+		"empty": {
+			initial: []pair{},
+			arg:     pair{1, "a"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.NewFunc(testCase.initial, comparePair)
+			check := func(op string, expected result, value pair, ok bool) {
+				t.Helper()
+				if value != expected.value || ok != expected.exist {
+					t.Errorf("%s: expected %v, %v, got %v, %v", op, expected.value, expected.exist, value, ok)
+				}
+			}
+			value, ok := ss.Lt(testCase.arg)
+			check("Lt", testCase.lt, value, ok)
+			value, ok = ss.Le(testCase.arg)
+			check("Le", testCase.le, value, ok)
+			value, ok = ss.Gt(testCase.arg)
+			check("Gt", testCase.gt, value, ok)
+			value, ok = ss.Ge(testCase.arg)
+			check("Ge", testCase.ge, value, ok)
+		})
+	}
+}
+
+func TestFuncIndex(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       []pair
+		arg           pair
+		expectedIndex int
+		expectedRight int
+	}{
+		"contains": {
+			initial:       []pair{{1, "a"}, {2, "a"}, {2, "b"}},
+			arg:           pair{2, "a"},
+			expectedIndex: 1,
+			expectedRight: 2,
+		},
+		"not contains": {
+			initial:       []pair{{1, "a"}, {2, "b"}},
+			arg:           pair{2, "a"},
+			expectedIndex: 1,
+			expectedRight: 1,
+		},
+		"empty": {
+			initial:       []pair{},
+			arg:           pair{1, "a"},
+			expectedIndex: 0,
+			expectedRight: 0,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.NewFunc(testCase.initial, comparePair)
+			if index := ss.Index(testCase.arg); index != testCase.expectedIndex {
+				t.Errorf("expected %v, got %v", testCase.expectedIndex, index)
+			}
+			if index := ss.IndexRight(testCase.arg); index != testCase.expectedRight {
+				t.Errorf("expected %v, got %v", testCase.expectedRight, index)
+			}
+		})
+	}
+}
+
+func TestFuncPop(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       []pair
+		arg           int
+		expectedValue pair
+		expectedError error
+		expected      []pair
+	}{
+		"ok": {
+			initial:       []pair{{1, "a"}, {2, "a"}, {3, "a"}},
+			arg:           1,
+			expectedValue: pair{2, "a"},
+			expected:      []pair{{1, "a"}, {3, "a"}},
+		},
+		"negative index": {
+			initial:       []pair{{1, "a"}, {2, "a"}, {3, "a"}},
+			arg:           -1,
+			expectedValue: pair{3, "a"},
+			expected:      []pair{{1, "a"}, {2, "a"}},
+		},
+		"index out of range": {
+			initial:       []pair{{1, "a"}},
+			arg:           1,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+		"empty": {
+			initial:       []pair{},
+			arg:           -1,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.NewFunc(testCase.initial, comparePair)
+			item, err := ss.GetItem(testCase.arg)
+			value, popErr := ss.Pop(testCase.arg)
+			if testCase.expectedError != nil {
+				if !errors.Is(err, testCase.expectedError) || !errors.Is(popErr, testCase.expectedError) {
+					t.Errorf("expected error %v, got %v, %v", testCase.expectedError, err, popErr)
+				}
+				return
+			}
+
+			if item != testCase.expectedValue || value != testCase.expectedValue {
+				t.Errorf("expected %v, got %v, %v", testCase.expectedValue, item, value)
+			}
+			assertEqualSlice(t, testCase.expected, slices.Collect(ss.Values()))
+		})
+	}
+}
+
+func TestFuncEquals(t *testing.T) {
+	t.Parallel()
+
+	compareFirst := func(a, b pair) int { return cmp.Compare(a.first, b.first) }
+	many := make([]pair, 0, 17)
+	for i := range 17 {
+		many = append(many, pair{i, "a"})
+	}
+	testCases := map[string]struct {
+		a, b     []pair
+		cmp      func(a, b pair) int
+		expected bool
+	}{
+		"equal":            {a: []pair{{1, "a"}, {2, "b"}}, b: []pair{{2, "b"}, {1, "a"}}, cmp: comparePair, expected: true},
+		"different":        {a: []pair{{1, "a"}, {2, "b"}}, b: []pair{{1, "a"}, {2, "c"}}, cmp: comparePair, expected: false},
+		"different length": {a: []pair{{1, "a"}, {2, "b"}}, b: []pair{{1, "a"}}, cmp: comparePair, expected: false},
+		"equal by cmp":     {a: []pair{{1, "a"}, {2, "b"}}, b: []pair{{1, "x"}, {2, "y"}}, cmp: compareFirst, expected: true},
+		"multiple buckets": {a: many, b: many, cmp: comparePair, expected: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := gosortedset.NewFunc(slices.Clone(testCase.a), testCase.cmp)
+			// build b one by one so that it is bucketed differently from a
+			b := gosortedset.NewFunc([]pair{}, testCase.cmp)
+			for _, v := range testCase.b {
+				b.Add(v)
+			}
+			if a.Equals(b) != testCase.expected || b.Equals(a) != testCase.expected {
+				t.Errorf("expected %v, got %v, %v", testCase.expected, a.Equals(b), b.Equals(a))
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package gosortedset_test
 
 import (
 	"errors"
+	"math"
 	"slices"
 	"testing"
 
@@ -159,6 +160,326 @@ func TestBackward(t *testing.T) {
 	}
 }
 
+func TestSlice(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial     []int
+		i, j        int
+		expectedIdx []int
+		expected    []int
+	}{
+		"ok": {
+			initial:     []int{1, 2, 3, 4, 5},
+			i:           1,
+			j:           3,
+			expectedIdx: []int{1, 2},
+			expected:    []int{2, 3},
+		},
+		"negative index": {
+			initial:     []int{1, 2, 3, 4, 5},
+			i:           -3,
+			j:           -1,
+			expectedIdx: []int{2, 3},
+			expected:    []int{3, 4},
+		},
+		"clamped": {
+			initial:     []int{1, 2, 3},
+			i:           -10,
+			j:           10,
+			expectedIdx: []int{0, 1, 2},
+			expected:    []int{1, 2, 3},
+		},
+		"i greater than j": {
+			initial:     []int{1, 2, 3},
+			i:           2,
+			j:           1,
+			expectedIdx: []int{},
+			expected:    []int{},
+		},
+		"multiple buckets": {
+			initial:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			i:           6,
+			j:           10,
+			expectedIdx: []int{6, 7, 8, 9},
+			expected:    []int{7, 8, 9, 10},
+		},
+		"empty": {
+			initial:     []int{},
+			i:           0,
+			j:           1,
+			expectedIdx: []int{},
+			expected:    []int{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			idx := make([]int, 0, len(testCase.expected))
+			actual := make([]int, 0, len(testCase.expected))
+			for i, v := range ss.Slice(testCase.i, testCase.j) {
+				idx = append(idx, i)
+				actual = append(actual, v)
+			}
+
+			assertEqualSlice(t, testCase.expectedIdx, idx)
+			assertEqualSlice(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestValuesFrom(t *testing.T) {
+	t.Parallel()
+
+	evens := []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34}
+	testCases := map[string]struct {
+		initial     []int
+		x           int
+		inclusive   bool
+		expectedIdx []int
+		expected    []int
+	}{
+		"inclusive": {
+			initial:     evens,
+			x:           30,
+			inclusive:   true,
+			expectedIdx: []int{14, 15, 16},
+			expected:    []int{30, 32, 34},
+		},
+		"exclusive": {
+			initial:     evens,
+			x:           30,
+			expectedIdx: []int{15, 16},
+			expected:    []int{32, 34},
+		},
+		"not contains": {
+			initial:     evens,
+			x:           29,
+			expectedIdx: []int{14, 15, 16},
+			expected:    []int{30, 32, 34},
+		},
+		"bucket boundary": {
+			initial:     evens,
+			x:           16,
+			expectedIdx: []int{8, 9, 10, 11, 12, 13, 14, 15, 16},
+			expected:    []int{18, 20, 22, 24, 26, 28, 30, 32, 34},
+		},
+		"before first": {
+			initial:     []int{1, 2, 3},
+			x:           0,
+			expectedIdx: []int{0, 1, 2},
+			expected:    []int{1, 2, 3},
+		},
+		"last exclusive": {
+			initial:     evens,
+			x:           34,
+			expectedIdx: []int{},
+			expected:    []int{},
+		},
+		"empty": {
+			initial:     []int{},
+			x:           1,
+			inclusive:   true,
+			expectedIdx: []int{},
+			expected:    []int{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(slices.Clone(testCase.initial))
+			idx := make([]int, 0, len(testCase.expected))
+			actual := make([]int, 0, len(testCase.expected))
+			for i, v := range ss.ValuesFrom(testCase.x, testCase.inclusive) {
+				idx = append(idx, i)
+				actual = append(actual, v)
+			}
+
+			assertEqualSlice(t, testCase.expectedIdx, idx)
+			assertEqualSlice(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestBackwardFrom(t *testing.T) {
+	t.Parallel()
+
+	evens := []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34}
+	testCases := map[string]struct {
+		initial     []int
+		x           int
+		inclusive   bool
+		expectedIdx []int
+		expected    []int
+	}{
+		"inclusive": {
+			initial:     evens,
+			x:           6,
+			inclusive:   true,
+			expectedIdx: []int{2, 1, 0},
+			expected:    []int{6, 4, 2},
+		},
+		"exclusive": {
+			initial:     evens,
+			x:           6,
+			expectedIdx: []int{1, 0},
+			expected:    []int{4, 2},
+		},
+		"not contains": {
+			initial:     evens,
+			x:           5,
+			inclusive:   true,
+			expectedIdx: []int{1, 0},
+			expected:    []int{4, 2},
+		},
+		"bucket boundary": {
+			initial:     evens,
+			x:           20,
+			expectedIdx: []int{8, 7, 6, 5, 4, 3, 2, 1, 0},
+			expected:    []int{18, 16, 14, 12, 10, 8, 6, 4, 2},
+		},
+		"after last": {
+			initial:     []int{1, 2, 3},
+			x:           100,
+			expectedIdx: []int{2, 1, 0},
+			expected:    []int{3, 2, 1},
+		},
+		"first exclusive": {
+			initial:     evens,
+			x:           2,
+			expectedIdx: []int{},
+			expected:    []int{},
+		},
+		"empty": {
+			initial:     []int{},
+			x:           1,
+			inclusive:   true,
+			expectedIdx: []int{},
+			expected:    []int{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(slices.Clone(testCase.initial))
+			idx := make([]int, 0, len(testCase.expected))
+			actual := make([]int, 0, len(testCase.expected))
+			for i, v := range ss.BackwardFrom(testCase.x, testCase.inclusive) {
+				idx = append(idx, i)
+				actual = append(actual, v)
+			}
+
+			assertEqualSlice(t, testCase.expectedIdx, idx)
+			assertEqualSlice(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestRange(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial                   []int
+		lo, hi                    int
+		expected                  []int
+		expectedInclusive         []int
+		expectedBackward          []int
+		expectedBackwardInclusive []int
+	}{
+		"ok": {
+			initial:                   []int{1, 2, 3, 4, 5},
+			lo:                        2,
+			hi:                        4,
+			expected:                  []int{2, 3},
+			expectedInclusive:         []int{2, 3, 4},
+			expectedBackward:          []int{3, 2},
+			expectedBackwardInclusive: []int{4, 3, 2},
+		},
+		"bounds not contained": {
+			initial:                   []int{1, 3, 5, 7},
+			lo:                        2,
+			hi:                        6,
+			expected:                  []int{3, 5},
+			expectedInclusive:         []int{3, 5},
+			expectedBackward:          []int{5, 3},
+			expectedBackwardInclusive: []int{5, 3},
+		},
+		"whole set": {
+			initial:                   []int{1, 2, 3},
+			lo:                        0,
+			hi:                        10,
+			expected:                  []int{1, 2, 3},
+			expectedInclusive:         []int{1, 2, 3},
+			expectedBackward:          []int{3, 2, 1},
+			expectedBackwardInclusive: []int{3, 2, 1},
+		},
+		"no elements": {
+			initial:                   []int{1, 2, 3},
+			lo:                        4,
+			hi:                        10,
+			expected:                  []int{},
+			expectedInclusive:         []int{},
+			expectedBackward:          []int{},
+			expectedBackwardInclusive: []int{},
+		},
+		"lo greater than hi": {
+			initial:                   []int{1, 2, 3},
+			lo:                        3,
+			hi:                        1,
+			expected:                  []int{},
+			expectedInclusive:         []int{},
+			expectedBackward:          []int{},
+			expectedBackwardInclusive: []int{},
+		},
+		"multiple buckets": {
+			initial:                   []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			lo:                        7,
+			hi:                        10,
+			expected:                  []int{7, 8, 9},
+			expectedInclusive:         []int{7, 8, 9, 10},
+			expectedBackward:          []int{9, 8, 7},
+			expectedBackwardInclusive: []int{10, 9, 8, 7},
+		},
+		"hi at bucket boundary": {
+			initial:                   []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			lo:                        6,
+			hi:                        9,
+			expected:                  []int{6, 7, 8},
+			expectedInclusive:         []int{6, 7, 8, 9},
+			expectedBackward:          []int{8, 7, 6},
+			expectedBackwardInclusive: []int{9, 8, 7, 6},
+		},
+		"empty": {
+			initial:                   []int{},
+			lo:                        0,
+			hi:                        10,
+			expected:                  []int{},
+			expectedInclusive:         []int{},
+			expectedBackward:          []int{},
+			expectedBackwardInclusive: []int{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			assertEqualSlice(t, testCase.expected, slices.Collect(ss.Range(testCase.lo, testCase.hi)))
+			assertEqualSlice(t, testCase.expectedInclusive, slices.Collect(ss.RangeInclusive(testCase.lo, testCase.hi)))
+			assertEqualSlice(t, testCase.expectedBackward, slices.Collect(ss.RangeBackward(testCase.lo, testCase.hi)))
+			assertEqualSlice(t, testCase.expectedBackwardInclusive, slices.Collect(ss.RangeBackwardInclusive(testCase.lo, testCase.hi)))
+		})
+	}
+}
+
 func TestLen(t *testing.T) {
 	t.Parallel()
 
@@ -230,6 +551,26 @@ func TestEquals(t *testing.T) {
 			arg:      gosortedset.New([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}),
 			expected: false,
 		},
+		"same elements in different buckets": {
+			rcv: gosortedset.New([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}),
+			arg: func() *gosortedset.SortedSet[int] {
+				ss := gosortedset.New([]int{})
+				for i := 1; i <= 17; i++ {
+					ss.Add(i)
+				}
+				return ss
+			}(),
+			expected: true,
+		},
+		"different bucket counts": {
+			rcv: gosortedset.New([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}),
+			arg: func() *gosortedset.SortedSet[int] {
+				ss := gosortedset.New([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50})
+				ss.DeleteRange(18, 51)
+				return ss
+			}(),
+			expected: true,
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -245,6 +586,63 @@ func TestEquals(t *testing.T) {
 
 }
 
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		rcv      []int
+		arg      []int
+		expected int
+	}{
+		"equal": {
+			rcv:      []int{1, 2, 3},
+			arg:      []int{1, 2, 3},
+			expected: 0,
+		},
+		"less": {
+			rcv:      []int{1, 2, 3},
+			arg:      []int{1, 2, 4},
+			expected: -1,
+		},
+		"greater": {
+			rcv:      []int{1, 3},
+			arg:      []int{1, 2, 4},
+			expected: 1,
+		},
+		"prefix": {
+			rcv:      []int{1, 2},
+			arg:      []int{1, 2, 3},
+			expected: -1,
+		},
+		"empty": {
+			rcv:      []int{},
+			arg:      []int{1},
+			expected: -1,
+		},
+		"both empty": {
+			rcv:      []int{},
+			arg:      []int{},
+			expected: 0,
+		},
+		"multiple buckets": {
+			rcv:      []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 18},
+			arg:      []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			expected: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result := gosortedset.New(testCase.rcv).Compare(gosortedset.New(testCase.arg))
+			if result != testCase.expected {
+				t.Errorf("expected %v, got %v", testCase.expected, result)
+			}
+		})
+	}
+}
+
 func TestAdd(t *testing.T) {
 	t.Parallel()
 
@@ -288,6 +686,17 @@ func TestAdd(t *testing.T) {
 			expected:        []int{1, 2, 3, 4, 5},
 			expectedBuckets: [][]int{{1, 2, 3, 4, 5}},
 		},
+		"add to left half after split": {
+			initial: []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32},
+			operation: func(ss *gosortedset.SortedSet[int]) {
+				for i := 34; i <= 50; i += 2 {
+					ss.Add(i)
+				}
+				ss.Add(1)
+			},
+			expected:        []int{1, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50},
+			expectedBuckets: [][]int{{1, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24}, {26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50}},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -303,6 +712,92 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+func TestAddSlice(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial         []int
+		arg             []int
+		expectedCount   int
+		expected        []int
+		expectedBuckets [][]int
+	}{
+		"ok": {
+			initial:         []int{1, 3, 5},
+			arg:             []int{4, 2},
+			expectedCount:   2,
+			expected:        []int{1, 2, 3, 4, 5},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 5}},
+		},
+		"duplicate": {
+			initial:         []int{1, 3, 5},
+			arg:             []int{3, 4, 4, 5},
+			expectedCount:   1,
+			expected:        []int{1, 3, 4, 5},
+			expectedBuckets: [][]int{{1, 3, 4, 5}},
+		},
+		"unsorted duplicates": {
+			initial:         []int{1},
+			arg:             []int{5, 3, 5, 3},
+			expectedCount:   2,
+			expected:        []int{1, 3, 5},
+			expectedBuckets: [][]int{{1, 3, 5}},
+		},
+		"add to empty": {
+			initial:         []int{},
+			arg:             []int{2, 1},
+			expectedCount:   2,
+			expected:        []int{1, 2},
+			expectedBuckets: [][]int{{1, 2}},
+		},
+		"add nothing": {
+			initial:         []int{1, 2},
+			arg:             []int{},
+			expectedCount:   0,
+			expected:        []int{1, 2},
+			expectedBuckets: [][]int{{1, 2}},
+		},
+		"rebucket": {
+			initial:         []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			arg:             []int{17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34},
+			expectedCount:   18,
+			expected:        []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}, {18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			arg := slices.Clone(testCase.arg)
+			if count := ss.AddSlice(arg); count != testCase.expectedCount {
+				t.Errorf("expected %v, got %v", testCase.expectedCount, count)
+			}
+			// the caller's slice must not be sorted nor compacted
+			assertEqualSlice(t, testCase.arg, arg)
+			assertEqualSlice(t, testCase.expected, slices.Collect(ss.Values()))
+			assertEqualBuckets(t, testCase.expectedBuckets, ss.Buckets())
+			if ss.Len() != len(testCase.expected) {
+				t.Errorf("expected length %v, got %v", len(testCase.expected), ss.Len())
+			}
+		})
+	}
+}
+
+func TestAddAll(t *testing.T) {
+	t.Parallel()
+
+	ss := gosortedset.New([]int{1, 3, 5})
+	other := gosortedset.New([]int{2, 3, 4})
+	if count := ss.AddAll(other.Values()); count != 2 {
+		t.Errorf("expected 2, got %v", count)
+	}
+	assertEqualSlice(t, []int{1, 2, 3, 4, 5}, slices.Collect(ss.Values()))
+	assertEqualSlice(t, []int{2, 3, 4}, slices.Collect(other.Values()))
+}
+
 func TestContains(t *testing.T) {
 	t.Parallel()
 
@@ -445,6 +940,12 @@ func TestLt(t *testing.T) {
 			expectedValue: 10,
 			expectedExist: true,
 		},
+		"bucket boundary": {
+			initial:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			arg:           9,
+			expectedValue: 8,
+			expectedExist: true,
+		},
 		"empty": {
 			initial:       []int{},
 			arg:           1,
@@ -553,6 +1054,12 @@ func TestGt(t *testing.T) {
 			expectedValue: 10,
 			expectedExist: true,
 		},
+		"bucket boundary": {
+			initial:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			arg:           8,
+			expectedValue: 9,
+			expectedExist: true,
+		},
 		"empty": {
 			initial:       []int{},
 			arg:           1,
@@ -797,6 +1304,401 @@ func TestPop(t *testing.T) {
 	}
 }
 
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial       []int
+		expectedMin   int
+		expectedMax   int
+		expectedError error
+		afterPop      []int
+	}{
+		"ok": {
+			initial:     []int{3, 1, 2, 5, 4},
+			expectedMin: 1,
+			expectedMax: 5,
+			afterPop:    []int{2, 3, 4},
+		},
+		"single element": {
+			initial:     []int{1},
+			expectedMin: 1,
+			expectedMax: 1,
+			afterPop:    []int{},
+		},
+		"multiple buckets": {
+			initial:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			expectedMin: 1,
+			expectedMax: 17,
+			afterPop:    []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		},
+		"empty": {
+			initial:       []int{},
+			expectedError: gosortedset.ErrEmpty,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			minimum, minErr := ss.Min()
+			maximum, maxErr := ss.Max()
+			if testCase.expectedError != nil {
+				if !errors.Is(minErr, testCase.expectedError) || !errors.Is(maxErr, testCase.expectedError) {
+					t.Errorf("expected error %v, got %v, %v", testCase.expectedError, minErr, maxErr)
+				}
+				if _, err := ss.PopMin(); !errors.Is(err, testCase.expectedError) {
+					t.Errorf("PopMin: expected error %v, got %v", testCase.expectedError, err)
+				}
+				if _, err := ss.PopMax(); !errors.Is(err, testCase.expectedError) {
+					t.Errorf("PopMax: expected error %v, got %v", testCase.expectedError, err)
+				}
+				return
+			}
+			if minimum != testCase.expectedMin || maximum != testCase.expectedMax {
+				t.Errorf("expected %v, %v, got %v, %v", testCase.expectedMin, testCase.expectedMax, minimum, maximum)
+			}
+
+			if v, err := ss.PopMin(); v != testCase.expectedMin || err != nil {
+				t.Errorf("PopMin: expected %v, nil, got %v, %v", testCase.expectedMin, v, err)
+			}
+			if ss.Len() > 0 {
+				if v, err := ss.PopMax(); v != testCase.expectedMax || err != nil {
+					t.Errorf("PopMax: expected %v, nil, got %v, %v", testCase.expectedMax, v, err)
+				}
+			}
+			assertEqualSlice(t, testCase.afterPop, slices.Collect(ss.Values()))
+		})
+	}
+}
+
+func TestPopMinMaxN(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial     []int
+		k           int
+		expectedMin []int
+		expectedMax []int
+		remaining   []int
+	}{
+		"ok": {
+			initial:     []int{1, 2, 3, 4, 5, 6},
+			k:           2,
+			expectedMin: []int{1, 2},
+			expectedMax: []int{6, 5},
+			remaining:   []int{3, 4},
+		},
+		"more than length": {
+			initial:     []int{1, 2, 3},
+			k:           5,
+			expectedMin: []int{1, 2, 3},
+			expectedMax: []int{},
+			remaining:   []int{},
+		},
+		"zero": {
+			initial:     []int{1, 2, 3},
+			k:           0,
+			expectedMin: []int{},
+			expectedMax: []int{},
+			remaining:   []int{1, 2, 3},
+		},
+		"negative": {
+			initial:     []int{1, 2, 3},
+			k:           -1,
+			expectedMin: []int{},
+			expectedMax: []int{},
+			remaining:   []int{1, 2, 3},
+		},
+		"multiple buckets": {
+			initial:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50},
+			k:           20,
+			expectedMin: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+			expectedMax: []int{50, 49, 48, 47, 46, 45, 44, 43, 42, 41, 40, 39, 38, 37, 36, 35, 34, 33, 32, 31},
+			remaining:   []int{21, 22, 23, 24, 25, 26, 27, 28, 29, 30},
+		},
+		"empty": {
+			initial:     []int{},
+			k:           1,
+			expectedMin: []int{},
+			expectedMax: []int{},
+			remaining:   []int{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			assertEqualSlice(t, testCase.expectedMin, ss.PopMinN(testCase.k))
+			assertEqualSlice(t, testCase.expectedMax, ss.PopMaxN(testCase.k))
+			assertEqualSlice(t, testCase.remaining, slices.Collect(ss.Values()))
+			if ss.Len() != len(testCase.remaining) {
+				t.Errorf("expected length %v, got %v", len(testCase.remaining), ss.Len())
+			}
+		})
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial         []int
+		lo, hi          int
+		expectedCount   int
+		expected        []int
+		expectedBuckets [][]int
+	}{
+		"ok": {
+			initial:         []int{1, 2, 3, 4, 5},
+			lo:              2,
+			hi:              4,
+			expectedCount:   2,
+			expected:        []int{1, 4, 5},
+			expectedBuckets: [][]int{{1, 4, 5}},
+		},
+		"bounds not contained": {
+			initial:         []int{1, 3, 5, 7},
+			lo:              2,
+			hi:              6,
+			expectedCount:   2,
+			expected:        []int{1, 7},
+			expectedBuckets: [][]int{{1, 7}},
+		},
+		"nothing removed": {
+			initial:         []int{1, 2, 3},
+			lo:              4,
+			hi:              10,
+			expectedCount:   0,
+			expected:        []int{1, 2, 3},
+			expectedBuckets: [][]int{{1, 2, 3}},
+		},
+		"lo greater than hi": {
+			initial:         []int{1, 2, 3},
+			lo:              3,
+			hi:              1,
+			expectedCount:   0,
+			expected:        []int{1, 2, 3},
+			expectedBuckets: [][]int{{1, 2, 3}},
+		},
+		"all": {
+			initial:         []int{1, 2, 3},
+			lo:              0,
+			hi:              10,
+			expectedCount:   3,
+			expected:        []int{},
+			expectedBuckets: [][]int{},
+		},
+		"across buckets": {
+			initial:         []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			lo:              5,
+			hi:              12,
+			expectedCount:   7,
+			expected:        []int{1, 2, 3, 4, 12, 13, 14, 15, 16, 17},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 12, 13, 14, 15, 16, 17}},
+		},
+		"whole first bucket": {
+			initial:         []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			lo:              0,
+			hi:              10,
+			expectedCount:   9,
+			expected:        []int{10, 11, 12, 13, 14, 15, 16, 17},
+			expectedBuckets: [][]int{{10, 11, 12, 13, 14, 15, 16, 17}},
+		},
+		"whole last bucket": {
+			initial:         []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			lo:              9,
+			hi:              20,
+			expectedCount:   9,
+			expected:        []int{1, 2, 3, 4, 5, 6, 7, 8},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 5, 6, 7, 8}},
+		},
+		"empty": {
+			initial:         []int{},
+			lo:              0,
+			hi:              10,
+			expectedCount:   0,
+			expected:        []int{},
+			expectedBuckets: [][]int{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			if count := ss.DeleteRange(testCase.lo, testCase.hi); count != testCase.expectedCount {
+				t.Errorf("expected %v, got %v", testCase.expectedCount, count)
+			}
+			assertEqualSlice(t, testCase.expected, slices.Collect(ss.Values()))
+			assertEqualBuckets(t, testCase.expectedBuckets, ss.Buckets())
+			if ss.Len() != len(testCase.expected) {
+				t.Errorf("expected length %v, got %v", len(testCase.expected), ss.Len())
+			}
+		})
+	}
+}
+
+func TestDeleteRangeMergeBeforeSplit(t *testing.T) {
+	t.Parallel()
+
+	// dropping the middle buckets lowers the split bound below the first bucket,
+	// while both trimmed edge buckets fall below the merge bound.
+	buckets := [][]int{make([]int, 0, 80)}
+	for i := range 80 {
+		buckets[0] = append(buckets[0], i)
+	}
+	for b := range 5 {
+		bucket := make([]int, 0, 10)
+		for i := range 10 {
+			bucket = append(bucket, 100+b*10+i)
+		}
+		buckets = append(buckets, bucket)
+	}
+	ss := gosortedset.New([]int{})
+	ss.SetBuckets(buckets, 130)
+
+	if count := ss.DeleteRange(102, 148); count != 46 {
+		t.Errorf("expected 46, got %v", count)
+	}
+	if err := ss.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	lengths := []int{}
+	for _, bucket := range ss.Buckets() {
+		lengths = append(lengths, len(bucket))
+	}
+	assertEqualSlice(t, []int{42, 42}, lengths)
+}
+
+func TestDiscardEmptyBucketSplit(t *testing.T) {
+	t.Parallel()
+
+	// removing the emptied bucket lowers the split bound below the first bucket.
+	buckets := [][]int{make([]int, 0, 80), {100}, {200, 201, 202, 203, 204, 205, 206, 207, 208, 209}}
+	for i := range 80 {
+		buckets[0] = append(buckets[0], i)
+	}
+	ss := gosortedset.New([]int{})
+	ss.SetBuckets(buckets, 91)
+
+	if !ss.Discard(100) {
+		t.Error("expected true, got false")
+	}
+	if err := ss.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	lengths := []int{}
+	for _, bucket := range ss.Buckets() {
+		lengths = append(lengths, len(bucket))
+	}
+	assertEqualSlice(t, []int{40, 40, 10}, lengths)
+}
+
+func TestPopRange(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial         []int
+		i, j            int
+		expectedValues  []int
+		expectedError   error
+		expected        []int
+		expectedBuckets [][]int
+	}{
+		"ok": {
+			initial:         []int{1, 2, 3, 4, 5},
+			i:               1,
+			j:               3,
+			expectedValues:  []int{2, 3},
+			expected:        []int{1, 4, 5},
+			expectedBuckets: [][]int{{1, 4, 5}},
+		},
+		"negative index": {
+			initial:         []int{1, 2, 3, 4, 5},
+			i:               -3,
+			j:               -1,
+			expectedValues:  []int{3, 4},
+			expected:        []int{1, 2, 5},
+			expectedBuckets: [][]int{{1, 2, 5}},
+		},
+		"empty range": {
+			initial:         []int{1, 2, 3},
+			i:               1,
+			j:               1,
+			expectedValues:  []int{},
+			expected:        []int{1, 2, 3},
+			expectedBuckets: [][]int{{1, 2, 3}},
+		},
+		"index out of range": {
+			initial:       []int{1, 2, 3},
+			i:             0,
+			j:             4,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+		"i greater than j": {
+			initial:       []int{1, 2, 3},
+			i:             2,
+			j:             1,
+			expectedError: gosortedset.ErrIndexOutOfRange,
+		},
+		"across buckets": {
+			initial:         []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			i:               4,
+			j:               11,
+			expectedValues:  []int{5, 6, 7, 8, 9, 10, 11},
+			expected:        []int{1, 2, 3, 4, 12, 13, 14, 15, 16, 17},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 12, 13, 14, 15, 16, 17}},
+		},
+		"all": {
+			initial:         []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			i:               0,
+			j:               17,
+			expectedValues:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			expected:        []int{},
+			expectedBuckets: [][]int{},
+		},
+		"empty": {
+			initial:         []int{},
+			i:               0,
+			j:               0,
+			expectedValues:  []int{},
+			expected:        []int{},
+			expectedBuckets: [][]int{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			values, err := ss.PopRange(testCase.i, testCase.j)
+			if testCase.expectedError != nil {
+				if !errors.Is(err, testCase.expectedError) {
+					t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertEqualSlice(t, testCase.expectedValues, values)
+			assertEqualSlice(t, testCase.expected, slices.Collect(ss.Values()))
+			assertEqualBuckets(t, testCase.expectedBuckets, ss.Buckets())
+			if ss.Len() != len(testCase.expected) {
+				t.Errorf("expected length %v, got %v", len(testCase.expected), ss.Len())
+			}
+		})
+	}
+}
+
 func TestIndex(t *testing.T) {
 	t.Parallel()
 
@@ -890,3 +1792,246 @@ func TestIndexRight(t *testing.T) {
 		})
 	}
 }
+
+func TestRankAfterUpdates(t *testing.T) {
+	t.Parallel()
+
+	const n = 1000
+	initial := make([]int, n)
+	for i := range initial {
+		initial[i] = i * 2
+	}
+	ss := gosortedset.New(slices.Clone(initial))
+	expected := initial
+
+	assertRank := func(t *testing.T) {
+		t.Helper()
+		if ss.Len() != len(expected) {
+			t.Fatalf("expected length %v, got %v", len(expected), ss.Len())
+		}
+		for i, v := range expected {
+			if got, err := ss.GetItem(i); err != nil || got != v {
+				t.Fatalf("GetItem(%v): expected %v, got %v, %v", i, v, got, err)
+			}
+			if got := ss.Index(v); got != i {
+				t.Fatalf("Index(%v): expected %v, got %v", v, i, got)
+			}
+			if got := ss.IndexRight(v); got != i+1 {
+				t.Fatalf("IndexRight(%v): expected %v, got %v", v, i+1, got)
+			}
+		}
+	}
+
+	// add the odd numbers in a scattered order so that buckets split in the middle of the set
+	for i := range n {
+		x := i*7919%n*2 + 1
+		ss.Add(x)
+		j, _ := slices.BinarySearch(expected, x)
+		expected = slices.Insert(expected, j, x)
+	}
+	if len(ss.Buckets()) == 8 {
+		t.Fatal("expected buckets to be split")
+	}
+	assertRank(t)
+
+	for i := range 100 {
+		idx := i * 13 % len(expected)
+		v, err := ss.Pop(idx)
+		if err != nil || v != expected[idx] {
+			t.Fatalf("Pop(%v): expected %v, got %v, %v", idx, expected[idx], v, err)
+		}
+		expected = slices.Delete(expected, idx, idx+1)
+	}
+	assertRank(t)
+
+	for i := range 100 {
+		x := i * 17
+		if ss.Discard(x) {
+			expected = slices.DeleteFunc(expected, func(v int) bool { return v == x })
+		}
+	}
+	assertRank(t)
+}
+
+func TestPopEmptiesMiddleBucket(t *testing.T) {
+	t.Parallel()
+
+	ss := gosortedset.NewWithOptions([]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, gosortedset.WithBucketRatio(1), gosortedset.WithMergeThreshold(0))
+	for _, x := range []int{5, 4, 6} {
+		if !ss.Discard(x) {
+			t.Fatalf("Discard(%v): expected true", x)
+		}
+	}
+
+	assertEqualSlice(t, []int{1, 2, 3, 7, 8, 9}, slices.Collect(ss.Values()))
+	assertEqualBuckets(t, [][]int{{1, 2, 3}, {7, 8, 9}}, ss.Buckets())
+}
+
+func TestBalanceAfterDeletes(t *testing.T) {
+	t.Parallel()
+
+	const n = 100000
+	initial := make([]int, n)
+	for i := range initial {
+		initial[i] = i
+	}
+	ss := gosortedset.New(slices.Clone(initial))
+	expected := map[int]bool{}
+	for _, v := range initial {
+		expected[v] = true
+	}
+
+	assertBalanced := func(t *testing.T) {
+		t.Helper()
+		if ss.Len() != len(expected) {
+			t.Fatalf("expected length %v, got %v", len(expected), ss.Len())
+		}
+		if err := ss.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		buckets := ss.Buckets()
+		limit := 2 * int(math.Ceil(math.Sqrt(float64(ss.Len())/16)))
+		if len(buckets) > limit {
+			t.Fatalf("expected at most %v buckets for %v elements, got %v", limit, ss.Len(), len(buckets))
+		}
+		for i, a := range buckets {
+			if len(a) == 0 {
+				t.Fatalf("buckets[%v] is empty", i)
+			}
+		}
+	}
+
+	// remove elements in a scattered order, mixing the ways to delete
+	for i := 0; len(expected) > 500; i++ {
+		switch i % 3 {
+		case 0:
+			x := i * 7919 % n
+			if ss.Discard(x) != expected[x] {
+				t.Fatalf("Discard(%v): expected %v", x, expected[x])
+			}
+			delete(expected, x)
+		case 1:
+			v, err := ss.Pop(i % ss.Len())
+			if err != nil || !expected[v] {
+				t.Fatalf("Pop(%v): got %v, %v", i%ss.Len(), v, err)
+			}
+			delete(expected, v)
+		case 2:
+			lo := i * 104729 % n
+			for x := lo; x < lo+3; x++ {
+				delete(expected, x)
+			}
+			ss.DeleteRange(lo, lo+3)
+		}
+		if i%1000 == 0 {
+			assertBalanced(t)
+		}
+	}
+	assertBalanced(t)
+
+	actual := slices.Collect(ss.Values())
+	for _, v := range actual {
+		if !expected[v] {
+			t.Fatalf("unexpected element %v", v)
+		}
+	}
+	if !slices.IsSorted(actual) || len(actual) != len(expected) {
+		t.Fatalf("expected %v sorted elements, got %v", len(expected), actual)
+	}
+}
+
+func TestIteratorModified(t *testing.T) {
+	t.Parallel()
+
+	// each iterator calls modify on the first element and continues the loop
+	iterators := map[string]func(ss *gosortedset.SortedSet[int], modify func()){
+		"all": func(ss *gosortedset.SortedSet[int], modify func()) {
+			for range ss.All() {
+				modify()
+			}
+		},
+		"values": func(ss *gosortedset.SortedSet[int], modify func()) {
+			for range ss.Values() {
+				modify()
+			}
+		},
+		"backward": func(ss *gosortedset.SortedSet[int], modify func()) {
+			for range ss.Backward() {
+				modify()
+			}
+		},
+		"slice": func(ss *gosortedset.SortedSet[int], modify func()) {
+			for range ss.Slice(1, -1) {
+				modify()
+			}
+		},
+		"range": func(ss *gosortedset.SortedSet[int], modify func()) {
+			for range ss.Range(2, 30) {
+				modify()
+			}
+		},
+		"values from": func(ss *gosortedset.SortedSet[int], modify func()) {
+			for range ss.ValuesFrom(4, true) {
+				modify()
+			}
+		},
+		"backward from": func(ss *gosortedset.SortedSet[int], modify func()) {
+			for range ss.BackwardFrom(30, false) {
+				modify()
+			}
+		},
+		"range backward": func(ss *gosortedset.SortedSet[int], modify func()) {
+			for range ss.RangeBackwardInclusive(2, 30) {
+				modify()
+			}
+		},
+	}
+	modifications := map[string]func(ss *gosortedset.SortedSet[int]){
+		"add":     func(ss *gosortedset.SortedSet[int]) { ss.Add(100) },
+		"discard": func(ss *gosortedset.SortedSet[int]) { ss.Discard(10) },
+		"pop":     func(ss *gosortedset.SortedSet[int]) { _, _ = ss.Pop(-1) },
+	}
+
+	for iterName, iterate := range iterators {
+		for modName, modify := range modifications {
+			t.Run(iterName+" "+modName, func(t *testing.T) {
+				t.Parallel()
+
+				ss := gosortedset.New([]int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34})
+				defer func() {
+					err, _ := recover().(error)
+					if !errors.Is(err, gosortedset.ErrModified) {
+						t.Errorf("expected panic with ErrModified, got %v", err)
+					}
+				}()
+				iterate(ss, func() { modify(ss) })
+			})
+		}
+	}
+
+	t.Run("break after modification", func(t *testing.T) {
+		t.Parallel()
+
+		ss := gosortedset.New([]int{1, 2, 3})
+		for v := range ss.Values() {
+			if v == 2 {
+				ss.Discard(v)
+				break
+			}
+		}
+		assertEqualSlice(t, []int{1, 3}, slices.Collect(ss.Values()))
+	})
+
+	t.Run("no change", func(t *testing.T) {
+		t.Parallel()
+
+		ss := gosortedset.New([]int{1, 2, 3})
+		var actual []int
+		for _, v := range ss.All() {
+			ss.Add(v)
+			ss.Discard(0)
+			actual = append(actual, v)
+		}
+		assertEqualSlice(t, []int{1, 2, 3}, actual)
+	})
+}
@@ -0,0 +1,86 @@
+package gosortedset
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SplitAt moves the elements less than x to left and the rest to right.
+// Whole buckets are moved without copying, so s is left empty.
+func (s *SortedSet[T]) SplitAt(x T) (left, right *SortedSet[T]) {
+	b, i := s.bisect(x, false)
+	return s.split(b, i)
+}
+
+// SplitIndex moves s[:i] to left and s[i:] to right.
+// Negative indices count from the end, and out of range indices are clamped as in Slice.
+// Whole buckets are moved without copying, so s is left empty.
+func (s *SortedSet[T]) SplitIndex(i int) (left, right *SortedSet[T]) {
+	b, j := s.locate(s.clamp(i))
+	return s.split(b, j)
+}
+
+// split s just before the position (b, i).
+func (s *SortedSet[T]) split(b, i int) (*SortedSet[T], *SortedSet[T]) {
+	left := slices.Clone(s.buckets[:b])
+	right := slices.Clone(s.buckets[b:])
+	if i > 0 {
+		left = append(left, right[0][:i:i])
+		right[0] = right[0][i:]
+	}
+
+	if len(left) >= 2 {
+		left = joinBuckets(left, len(left)-2, s.options())
+	}
+	if len(right) >= 2 {
+		right = joinBuckets(right, 0, s.options())
+	}
+
+	l, r := &SortedSet[T]{buckets: left, opts: s.opts}, &SortedSet[T]{buckets: right, opts: s.opts}
+	for _, a := range left {
+		l.size += len(a)
+	}
+	r.size = s.size - l.size
+	if s.shared != nil {
+		l.shareAll()
+		r.shareAll()
+	}
+	l.splitOversized()
+	r.splitOversized()
+	l.recount()
+	r.recount()
+	s.buckets, s.size, s.shared, s.sizes = nil, 0, nil, nil
+	s.mods++
+
+	return l, r
+}
+
+// Concat returns a set with the elements of a followed by the elements of b.
+// Every element of a must be less than every element of b, otherwise it returns ErrOverlap.
+// Whole buckets are moved without copying, so a and b are left empty.
+func Concat[T cmp.Ordered](a, b *SortedSet[T]) (*SortedSet[T], error) {
+	aMax, aErr := a.Max()
+	bMin, bErr := b.Min()
+	if aErr == nil && bErr == nil && aMax >= bMin {
+		return nil, ErrOverlap
+	}
+
+	s := &SortedSet[T]{opts: a.opts}
+	s.buckets = slices.Concat(a.buckets, b.buckets)
+	s.size = a.size + b.size
+	if a.size > 0 && b.size > 0 {
+		s.buckets = joinBuckets(s.buckets, len(a.buckets)-1, s.options())
+	}
+	if a.shared != nil || b.shared != nil {
+		s.shareAll()
+	}
+	// the buckets of b were bounded by the options of b, which may differ from those of a
+	s.splitOversized()
+	s.recount()
+	a.buckets, a.size, a.shared, a.sizes = nil, 0, nil, nil
+	b.buckets, b.size, b.shared, b.sizes = nil, 0, nil, nil
+	a.mods++
+	b.mods++
+
+	return s, nil
+}
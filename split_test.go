@@ -0,0 +1,227 @@
+package gosortedset_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestSplitAt(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial      []int
+		arg          int
+		left         []int
+		right        []int
+		leftBuckets  [][]int
+		rightBuckets [][]int
+	}{
+		"ok": {
+			initial:      []int{1, 2, 3, 4, 5},
+			arg:          3,
+			left:         []int{1, 2},
+			right:        []int{3, 4, 5},
+			leftBuckets:  [][]int{{1, 2}},
+			rightBuckets: [][]int{{3, 4, 5}},
+		},
+		"not contains": {
+			initial:      []int{1, 2, 4, 5},
+			arg:          3,
+			left:         []int{1, 2},
+			right:        []int{4, 5},
+			leftBuckets:  [][]int{{1, 2}},
+			rightBuckets: [][]int{{4, 5}},
+		},
+		"all to left": {
+			initial:      []int{1, 2, 3},
+			arg:          4,
+			left:         []int{1, 2, 3},
+			right:        []int{},
+			leftBuckets:  [][]int{{1, 2, 3}},
+			rightBuckets: [][]int{},
+		},
+		"all to right": {
+			initial:      []int{1, 2, 3},
+			arg:          0,
+			left:         []int{},
+			right:        []int{1, 2, 3},
+			leftBuckets:  [][]int{},
+			rightBuckets: [][]int{{1, 2, 3}},
+		},
+		"empty": {
+			initial:      []int{},
+			arg:          0,
+			left:         []int{},
+			right:        []int{},
+			leftBuckets:  [][]int{},
+			rightBuckets: [][]int{},
+		},
+		"multiple buckets": {
+			initial:      []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50},
+			arg:          30,
+			left:         []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29},
+			right:        []int{30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50},
+			leftBuckets:  [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, {15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29}},
+			rightBuckets: [][]int{{30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			left, right := ss.SplitAt(testCase.arg)
+
+			assertEqualSlice(t, testCase.left, slices.Collect(left.Values()))
+			assertEqualSlice(t, testCase.right, slices.Collect(right.Values()))
+			assertEqualBuckets(t, testCase.leftBuckets, left.Buckets())
+			assertEqualBuckets(t, testCase.rightBuckets, right.Buckets())
+			if left.Len() != len(testCase.left) || right.Len() != len(testCase.right) {
+				t.Errorf("expected length %v, %v, got %v, %v", len(testCase.left), len(testCase.right), left.Len(), right.Len())
+			}
+			if ss.Len() != 0 {
+				t.Errorf("expected the original set to be empty, got %v", ss)
+			}
+
+			// adding to the left half must not affect the right half
+			left.Add(testCase.arg - 1)
+			assertEqualSlice(t, testCase.right, slices.Collect(right.Values()))
+		})
+	}
+}
+
+func TestSplitIndex(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial []int
+		arg     int
+		left    []int
+		right   []int
+	}{
+		"ok": {
+			initial: []int{1, 2, 3, 4, 5},
+			arg:     2,
+			left:    []int{1, 2},
+			right:   []int{3, 4, 5},
+		},
+		"negative index": {
+			initial: []int{1, 2, 3, 4, 5},
+			arg:     -1,
+			left:    []int{1, 2, 3, 4},
+			right:   []int{5},
+		},
+		"clamped": {
+			initial: []int{1, 2, 3},
+			arg:     10,
+			left:    []int{1, 2, 3},
+			right:   []int{},
+		},
+		"multiple buckets": {
+			initial: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			arg:     8,
+			left:    []int{1, 2, 3, 4, 5, 6, 7, 8},
+			right:   []int{9, 10, 11, 12, 13, 14, 15, 16, 17},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			left, right := ss.SplitIndex(testCase.arg)
+
+			assertEqualSlice(t, testCase.left, slices.Collect(left.Values()))
+			assertEqualSlice(t, testCase.right, slices.Collect(right.Values()))
+			if left.Len() != len(testCase.left) || right.Len() != len(testCase.right) {
+				t.Errorf("expected length %v, %v, got %v, %v", len(testCase.left), len(testCase.right), left.Len(), right.Len())
+			}
+		})
+	}
+}
+
+func TestConcat(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b            []int
+		aOptions        []gosortedset.Option
+		expected        []int
+		expectedBuckets [][]int
+		expectedError   error
+	}{
+		"ok": {
+			a:               []int{1, 2, 3},
+			b:               []int{4, 5},
+			expected:        []int{1, 2, 3, 4, 5},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 5}},
+		},
+		"empty": {
+			a:               []int{},
+			b:               []int{4, 5},
+			expected:        []int{4, 5},
+			expectedBuckets: [][]int{{4, 5}},
+		},
+		"both empty": {
+			a:               []int{},
+			b:               []int{},
+			expected:        []int{},
+			expectedBuckets: [][]int{},
+		},
+		"overlap": {
+			a:             []int{1, 2, 3},
+			b:             []int{3, 4, 5},
+			expectedError: gosortedset.ErrOverlap,
+		},
+		"multiple buckets": {
+			a:               []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			b:               []int{18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34},
+			expected:        []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34},
+			expectedBuckets: [][]int{{1, 2, 3, 4, 5, 6, 7, 8}, {9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25}, {26, 27, 28, 29, 30, 31, 32, 33, 34}},
+		},
+		"different options": {
+			a:               []int{1},
+			b:               []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26},
+			aOptions:        []gosortedset.Option{gosortedset.WithSplitRatio(1)},
+			expected:        []int{1, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26},
+			expectedBuckets: [][]int{{1, 10}, {11, 12}, {13, 14}, {15, 16, 17}, {18, 19, 20, 21}, {22, 23, 24, 25, 26}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := gosortedset.NewWithOptions(testCase.a, testCase.aOptions...), gosortedset.New(testCase.b)
+			ss, err := gosortedset.Concat(a, b)
+			if testCase.expectedError != nil {
+				if !errors.Is(err, testCase.expectedError) {
+					t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+				}
+				assertEqualSlice(t, testCase.a, slices.Collect(a.Values()))
+				assertEqualSlice(t, testCase.b, slices.Collect(b.Values()))
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertEqualSlice(t, testCase.expected, slices.Collect(ss.Values()))
+			assertEqualBuckets(t, testCase.expectedBuckets, ss.Buckets())
+			if err := ss.Validate(); err != nil {
+				t.Error(err)
+			}
+			if ss.Len() != len(testCase.expected) {
+				t.Errorf("expected length %v, got %v", len(testCase.expected), ss.Len())
+			}
+			if a.Len() != 0 || b.Len() != 0 {
+				t.Errorf("expected the operands to be empty, got %v, %v", a, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package gosortedset
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"reflect"
+)
+
+// The stream format written by WriteTo is
+//
+//	magic "GOSS" | version byte | kind byte | frame ... | uvarint 0
+//
+// and each frame holds one bucket:
+//
+//	uvarint number of elements | uvarint payload length | payload | CRC-32 (IEEE) of the payload in little endian
+//
+// The payload is encoded in the same way as the elements of MarshalBinary,
+// starting over at every frame so that frames can be decoded one by one.
+const (
+	streamMagic   = "GOSS"
+	streamVersion = 1
+)
+
+// WriteTo implements io.WriterTo. It writes s to w one bucket at a time,
+// so the memory used does not depend on the size of s.
+func (s *SortedSet[T]) WriteTo(w io.Writer) (int64, error) {
+	kind := kindOf[T]()
+	var written int64
+	write := func(p []byte) error {
+		n, err := w.Write(p)
+		written += int64(n)
+		return err
+	}
+
+	if err := write(append([]byte(streamMagic), streamVersion, byte(kind))); err != nil {
+		return written, err
+	}
+
+	var payload, frame []byte
+	for _, a := range s.buckets {
+		e := &elemEncoder[T]{kind: kind}
+		payload = payload[:0]
+		for _, v := range a {
+			payload = e.append(payload, v)
+		}
+
+		frame = binary.AppendUvarint(frame[:0], uint64(len(a)))
+		frame = binary.AppendUvarint(frame, uint64(len(payload)))
+		frame = append(frame, payload...)
+		frame = binary.LittleEndian.AppendUint32(frame, crc32.ChecksumIEEE(payload))
+		if err := write(frame); err != nil {
+			return written, err
+		}
+	}
+
+	if err := write(binary.AppendUvarint(nil, 0)); err != nil {
+		return written, err
+	}
+	return written, nil
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// countingReader counts the bytes consumed through it.
+type countingReader struct {
+	r byteReader
+	n int64
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ReadFrom implements io.ReaderFrom. It replaces the elements of s with a stream written by WriteTo,
+// rebuilding the buckets frame by frame. If r does not implement io.ByteReader, it is wrapped with bufio.Reader,
+// which may read past the end of the stream.
+// On error, s is left unchanged.
+func (s *SortedSet[T]) ReadFrom(r io.Reader) (int64, error) {
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	cr := &countingReader{r: br}
+
+	buckets, size, err := readStream[T](cr)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return cr.n, err
+	}
+
+	s.buckets, s.size, s.shared = buckets, size, nil
+	s.splitOversized()
+	s.recount()
+	s.mods++
+	return cr.n, nil
+}
+
+func readStream[T cmp.Ordered](r *countingReader) ([][]T, int, error) {
+	kind := kindOf[T]()
+	header := make([]byte, len(streamMagic)+2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, 0, err
+	}
+	if string(header[:len(streamMagic)]) != streamMagic {
+		return nil, 0, fmt.Errorf("%w: not a sorted set stream", ErrInvalidEncoding)
+	}
+	if v := header[len(streamMagic)]; v != streamVersion {
+		return nil, 0, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, v)
+	}
+	if k := elemKind(header[len(streamMagic)+1]); k != kind {
+		return nil, 0, fmt.Errorf("%w: element kind %d does not match %v", ErrInvalidEncoding, k, reflect.TypeFor[T]())
+	}
+
+	var buckets [][]T
+	var prev T
+	size := 0
+	payload := &bytes.Buffer{}
+	for {
+		count, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, 0, err
+		}
+		if count == 0 {
+			return buckets, size, nil
+		}
+		length, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, 0, err
+		}
+		// every element takes at least one byte
+		if count > length {
+			return nil, 0, fmt.Errorf("%w: frame %d is malformed", ErrInvalidEncoding, len(buckets))
+		}
+
+		// copy instead of allocating length bytes up front, so that a broken length cannot exhaust memory
+		payload.Reset()
+		if _, err := io.CopyN(payload, r, int64(length)); err != nil {
+			return nil, 0, err
+		}
+		var sum [4]byte
+		if _, err := io.ReadFull(r, sum[:]); err != nil {
+			return nil, 0, err
+		}
+		if binary.LittleEndian.Uint32(sum[:]) != crc32.ChecksumIEEE(payload.Bytes()) {
+			return nil, 0, fmt.Errorf("%w: checksum mismatch in frame %d", ErrInvalidEncoding, len(buckets))
+		}
+
+		a := make([]T, 0, count)
+		d := &elemDecoder[T]{kind: kind}
+		data := payload.Bytes()
+		for range count {
+			v, rest, err := d.read(data)
+			if err != nil {
+				return nil, 0, err
+			}
+			data = rest
+			if (size > 0 || len(a) > 0) && cmp.Compare(prev, v) >= 0 {
+				return nil, 0, fmt.Errorf("%w: frame %d is not in ascending order", ErrInvalidEncoding, len(buckets))
+			}
+			a = append(a, v)
+			prev = v
+		}
+		if len(data) != 0 {
+			return nil, 0, fmt.Errorf("%w: frame %d has %d trailing bytes", ErrInvalidEncoding, len(buckets), len(data))
+		}
+
+		buckets = append(buckets, a)
+		size += len(a)
+	}
+}
@@ -0,0 +1,179 @@
+package gosortedset_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestWriteToReadFrom(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		initial []string
+	}{
+		"ok": {
+			initial: []string{"b", "a", "c"},
+		},
+		"empty": {
+			initial: []string{},
+		},
+		"multiple buckets": {
+			initial: func() []string {
+				a := make([]string, 0, 10000)
+				for i := range 10000 {
+					a = append(a, "key-"+strconv.Itoa(i))
+				}
+				return a
+			}(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New(testCase.initial)
+			buf := &bytes.Buffer{}
+			written, err := ss.WriteTo(buf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if written != int64(buf.Len()) {
+				t.Errorf("expected %v bytes written, got %v", buf.Len(), written)
+			}
+
+			// data after the stream must be left in the reader
+			_, _ = buf.WriteString("rest")
+
+			decoded := gosortedset.New([]string{"z"})
+			read, err := decoded.ReadFrom(buf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if read != written {
+				t.Errorf("expected %v bytes read, got %v", written, read)
+			}
+			if buf.String() != "rest" {
+				t.Errorf("expected %q to be left, got %q", "rest", buf.String())
+			}
+
+			assertEqualSlice(t, slices.Collect(ss.Values()), slices.Collect(decoded.Values()))
+			assertEqualBuckets(t, ss.Buckets(), decoded.Buckets())
+			if decoded.Len() != ss.Len() {
+				t.Errorf("expected length %v, got %v", ss.Len(), decoded.Len())
+			}
+		})
+	}
+}
+
+// onlyReader hides every method except Read.
+type onlyReader struct {
+	r io.Reader
+}
+
+func (o onlyReader) Read(p []byte) (int, error) {
+	return o.r.Read(p)
+}
+
+func TestReadFromPlainReader(t *testing.T) {
+	t.Parallel()
+
+	a := make([]int, 0, 1000)
+	for i := range 1000 {
+		a = append(a, i*7-300)
+	}
+	ss := gosortedset.New(a)
+	buf := &bytes.Buffer{}
+	if _, err := ss.WriteTo(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded := gosortedset.New([]int{})
+	if _, err := decoded.ReadFrom(onlyReader{buf}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertEqualSlice(t, a, slices.Collect(decoded.Values()))
+}
+
+func TestReadFromLargerBuckets(t *testing.T) {
+	t.Parallel()
+
+	// buckets written by a set with larger ratios exceed the default split bound
+	a := make([]int, 0, 400)
+	for i := range 400 {
+		a = append(a, i)
+	}
+	ss := gosortedset.NewWithOptions(slices.Clone(a), gosortedset.WithBucketRatio(100), gosortedset.WithSplitRatio(100))
+	buf := &bytes.Buffer{}
+	if _, err := ss.WriteTo(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded := gosortedset.New([]int{})
+	if _, err := decoded.ReadFrom(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertEqualSlice(t, a, slices.Collect(decoded.Values()))
+	if err := decoded.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReadFromError(t *testing.T) {
+	t.Parallel()
+
+	valid := &bytes.Buffer{}
+	if _, err := gosortedset.New([]int{1, 2, 3}).WriteTo(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := valid.Bytes()
+
+	corrupt := slices.Clone(data)
+	// the first byte of the payload of the first frame
+	corrupt[len("GOSS")+2+2]++
+
+	testCases := map[string]struct {
+		data          []byte
+		expectedError error
+	}{
+		"empty": {
+			data:          []byte{},
+			expectedError: io.ErrUnexpectedEOF,
+		},
+		"not a stream": {
+			data:          []byte("not a stream"),
+			expectedError: gosortedset.ErrInvalidEncoding,
+		},
+		"truncated": {
+			data:          data[:len(data)-3],
+			expectedError: io.ErrUnexpectedEOF,
+		},
+		"no end marker": {
+			data:          data[:len(data)-1],
+			expectedError: io.ErrUnexpectedEOF,
+		},
+		"checksum mismatch": {
+			data:          corrupt,
+			expectedError: gosortedset.ErrInvalidEncoding,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New([]int{10, 20})
+			_, err := ss.ReadFrom(bytes.NewReader(testCase.data))
+			if !errors.Is(err, testCase.expectedError) {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+			assertEqualSlice(t, []int{10, 20}, slices.Collect(ss.Values()))
+		})
+	}
+}
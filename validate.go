@@ -0,0 +1,63 @@
+package gosortedset
+
+import "fmt"
+
+// InvariantError is returned by Validate when the internal structure of a set is broken.
+type InvariantError struct {
+	// Bucket is the index of the offending bucket, or -1 if the error is not about a single bucket.
+	Bucket int
+	Reason string
+}
+
+func (e *InvariantError) Error() string {
+	if e.Bucket < 0 {
+		return "invalid set: " + e.Reason
+	}
+	return fmt.Sprintf("invalid set: bucket %d: %s", e.Bucket, e.Reason)
+}
+
+func invariantError(b int, format string, args ...any) error {
+	return &InvariantError{Bucket: b, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Validate checks the internal structure of s and returns an *InvariantError describing the first problem found:
+// an empty bucket, elements out of strictly ascending order within or across buckets,
+// a bucket longer than the split bound, or a size which differs from the number of elements in the buckets.
+// It takes O(n) time and is meant for tests and debug builds.
+func (s *SortedSet[T]) Validate() error {
+	bound := len(s.buckets) * s.options().splitRatio
+	if s.shared != nil && len(s.shared) != len(s.buckets) {
+		return invariantError(-1, "%d shared flags for %d buckets", len(s.shared), len(s.buckets))
+	}
+	if len(s.sizes) != len(s.buckets)+1 && !(len(s.buckets) == 0 && s.sizes == nil) {
+		return invariantError(-1, "rank index covers %d buckets instead of %d", len(s.sizes)-1, len(s.buckets))
+	}
+
+	n := 0
+	for b, a := range s.buckets {
+		if len(a) == 0 {
+			return invariantError(b, "empty bucket")
+		}
+		if len(a) > bound {
+			return invariantError(b, "%d elements exceed the split bound %d", len(a), bound)
+		}
+		if b > 0 {
+			if prev := s.buckets[b-1]; prev[len(prev)-1] >= a[0] {
+				return invariantError(b, "first element %v is not greater than the last element %v of bucket %d", a[0], prev[len(prev)-1], b-1)
+			}
+		}
+		for i := 1; i < len(a); i++ {
+			if a[i-1] >= a[i] {
+				return invariantError(b, "element %d (%v) is not greater than element %d (%v)", i, a[i], i-1, a[i-1])
+			}
+		}
+		if p := s.sizes.prefix(b); p != n {
+			return invariantError(b, "rank index counts %d elements before the bucket instead of %d", p, n)
+		}
+		n += len(a)
+	}
+	if n != s.size {
+		return invariantError(-1, "size is %d but the buckets hold %d elements", s.size, n)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package gosortedset_test
+
+import (
+	"errors"
+	"testing"
+
+	gosortedset "github.com/ikura-hamu/go-sorted_set"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		buckets        [][]int
+		size           int
+		expectedBucket int
+		expectedError  bool
+	}{
+		"ok": {
+			buckets: [][]int{{1, 2, 3}, {4, 5}},
+			size:    5,
+		},
+		"empty set": {
+			buckets: [][]int{},
+			size:    0,
+		},
+		"empty bucket": {
+			buckets:        [][]int{{1, 2, 3}, {}, {4, 5}},
+			size:           5,
+			expectedBucket: 1,
+			expectedError:  true,
+		},
+		"not sorted in bucket": {
+			buckets:        [][]int{{1, 2, 3}, {5, 4}},
+			size:           5,
+			expectedBucket: 1,
+			expectedError:  true,
+		},
+		"duplicate in bucket": {
+			buckets:        [][]int{{1, 2, 2}, {4, 5}},
+			size:           5,
+			expectedBucket: 0,
+			expectedError:  true,
+		},
+		"not sorted across buckets": {
+			buckets:        [][]int{{1, 2, 3}, {3, 4}},
+			size:           5,
+			expectedBucket: 1,
+			expectedError:  true,
+		},
+		"wrong size": {
+			buckets:        [][]int{{1, 2, 3}, {4, 5}},
+			size:           6,
+			expectedBucket: -1,
+			expectedError:  true,
+		},
+		"too large bucket": {
+			buckets:        [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25}},
+			size:           25,
+			expectedBucket: 0,
+			expectedError:  true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := gosortedset.New([]int{})
+			ss.SetBuckets(testCase.buckets, testCase.size)
+			err := ss.Validate()
+			if !testCase.expectedError {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var invariantErr *gosortedset.InvariantError
+			if !errors.As(err, &invariantErr) {
+				t.Fatalf("expected InvariantError, got %v", err)
+			}
+			if invariantErr.Bucket != testCase.expectedBucket {
+				t.Errorf("expected bucket %v, got %v (%v)", testCase.expectedBucket, invariantErr.Bucket, err)
+			}
+		})
+	}
+}
+
+func TestValidateAfterOperations(t *testing.T) {
+	t.Parallel()
+
+	initial := func() []int {
+		a := make([]int, 0, 2000)
+		for i := range 2000 {
+			a = append(a, i*2)
+		}
+		return a
+	}
+
+	testCases := map[string]struct {
+		operation func(ss *gosortedset.SortedSet[int]) *gosortedset.SortedSet[int]
+	}{
+		"add": {
+			operation: func(ss *gosortedset.SortedSet[int]) *gosortedset.SortedSet[int] {
+				for i := range 2000 {
+					ss.Add(i*2 + 1)
+				}
+				return ss
+			},
+		},
+		"pop and discard": {
+			operation: func(ss *gosortedset.SortedSet[int]) *gosortedset.SortedSet[int] {
+				for i := range 1500 {
+					if i%2 == 0 {
+						_, _ = ss.Pop(i * 31 % ss.Len())
+					} else {
+						ss.Discard(i * 7)
+					}
+				}
+				return ss
+			},
+		},
+		"delete range": {
+			operation: func(ss *gosortedset.SortedSet[int]) *gosortedset.SortedSet[int] {
+				ss.DeleteRange(100, 3900)
+				return ss
+			},
+		},
+		"split left": {
+			operation: func(ss *gosortedset.SortedSet[int]) *gosortedset.SortedSet[int] {
+				left, _ := ss.SplitAt(400)
+				return left
+			},
+		},
+		"split right": {
+			operation: func(ss *gosortedset.SortedSet[int]) *gosortedset.SortedSet[int] {
+				_, right := ss.SplitAt(10)
+				return right
+			},
+		},
+		"split ratio below bucket ratio": {
+			operation: func(ss *gosortedset.SortedSet[int]) *gosortedset.SortedSet[int] {
+				return gosortedset.NewWithOptions(initial(), gosortedset.WithBucketRatio(100), gosortedset.WithSplitRatio(2))
+			},
+		},
+		"snapshot": {
+			operation: func(ss *gosortedset.SortedSet[int]) *gosortedset.SortedSet[int] {
+				snapshot := ss.Snapshot()
+				for i := range 1000 {
+					_, _ = ss.PopMin()
+					snapshot.Add(i*2 + 1)
+				}
+				return snapshot
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ss := testCase.operation(gosortedset.New(initial()))
+			if err := ss.Validate(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}